@@ -0,0 +1,67 @@
+package blocks
+
+// Children returns the nested child blocks of the block, whichever block type holds them. They are
+// only set on blocks built to be created, Notion returns HasChildren instead.
+func (b Block) Children() []Block {
+	if p := textProperty(&b); *p != nil {
+		return (*p).Children
+	}
+
+	switch {
+	case b.Template != nil:
+		return b.Template.Children
+	case b.Table != nil:
+		return b.Table.Children
+	case b.ColumnList != nil:
+		return b.ColumnList.Children
+	case b.Column != nil:
+		return b.Column.Children
+	case b.SyncedBlock != nil:
+		return b.SyncedBlock.Children
+	}
+
+	return nil
+}
+
+// WithoutChildren returns a copy of the block without its nested child blocks.
+func (b Block) WithoutChildren() Block {
+	return b.WithChildren(nil)
+}
+
+// WithChildren returns a copy of the block with the given nested child blocks instead of its own.
+// Blocks that cannot have children are returned as is.
+func (b Block) WithChildren(children []Block) Block {
+	if p := textProperty(&b); *p != nil {
+		prop := **p
+		prop.Children = children
+		*p = &prop
+		return b
+	}
+
+	switch {
+	case b.Template != nil:
+		tmpl := *b.Template
+		tmpl.Children = children
+		b.Template = &tmpl
+	case b.Table != nil:
+		table := *b.Table
+		table.Children = children
+		b.Table = &table
+	case b.ColumnList != nil:
+		b.ColumnList = &ColumnList{Children: children}
+	case b.Column != nil:
+		b.Column = &Column{Children: children}
+	case b.SyncedBlock != nil:
+		sb := *b.SyncedBlock
+		sb.Children = children
+		b.SyncedBlock = &sb
+	}
+
+	return b
+}
+
+// RequiresChildren reports whether Notion only creates the block together with its children: a
+// table with its rows, a column list with its columns and a column with its content.
+func (b Block) RequiresChildren() bool {
+	return b.Table != nil || b.ColumnList != nil || b.Column != nil
+}
@@ -0,0 +1,52 @@
+package blocks
+
+import (
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestBlock_Children(t *testing.T) {
+	child := Block{Type: &BTParagraph, Paragraph: &Property{RichText: api.NewTexts("child")}}
+
+	tests := []struct {
+		name     string
+		block    Block
+		required bool
+	}{
+		{"toggle", Block{Type: &BTToggle, Toggle: &Property{Color: "red", Children: []Block{child}}}, false},
+		{"template", Block{Type: &BTTemplate, Template: &TemplateBlock{Children: []Block{child}}}, false},
+		{"synced block", Block{Type: &BTSyncedBlock, SyncedBlock: &SyncedBlock{Children: []Block{child}}}, false},
+		{"table", Block{Type: &BTTable, Table: &TableBlock{TableWidth: 1, Children: []Block{child}}}, true},
+		{"column list", Block{Type: &BTColumnList, ColumnList: &ColumnList{Children: []Block{child}}}, true},
+		{"column", Block{Type: &BTColumn, Column: &Column{Children: []Block{child}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.block.Children(); len(got) != 1 {
+				t.Errorf("expected 1 child, got %d", len(got))
+			}
+			if got := tt.block.WithoutChildren().Children(); got != nil {
+				t.Errorf("expected no children, got %d", len(got))
+			}
+			if got := tt.block.WithChildren([]Block{child, child}).Children(); len(got) != 2 {
+				t.Errorf("expected 2 children, got %d", len(got))
+			}
+			if got := tt.block.Children(); len(got) != 1 {
+				t.Error("expected WithoutChildren and WithChildren not to modify the block")
+			}
+			if got := tt.block.RequiresChildren(); got != tt.required {
+				t.Errorf("expected RequiresChildren %v, got %v", tt.required, got)
+			}
+		})
+	}
+
+	toggle := tests[0].block.WithoutChildren()
+	if toggle.Toggle.Color != "red" {
+		t.Error("expected WithoutChildren to keep the content of the block")
+	}
+	if got := (Block{Type: &BTDivider, Divider: &Divider{}}).Children(); got != nil {
+		t.Errorf("expected no children for a divider, got %d", len(got))
+	}
+}
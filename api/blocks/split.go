@@ -0,0 +1,181 @@
+package blocks
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// SplitBlocks returns a copy of the blocks, and of their children, with their rich text split by
+// api.SplitRichText so that every run fits in Notion's limits. A paragraph, heading, list item,
+// to-do, toggle, quote, callout, code or template block whose text still has more than
+// api.MaxRichTextElements runs is split into several blocks of the same type; the first one keeps
+// the children, and the checked state, icon or caption, which would otherwise be repeated. It fails if a caption, table cell or title still has too many runs.
+func SplitBlocks(bs []Block) ([]Block, error) {
+	if bs == nil {
+		return nil, nil
+	}
+
+	ret := make([]Block, 0, len(bs))
+	for i, b := range bs {
+		split, err := splitBlock(b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		ret = append(ret, split...)
+	}
+
+	return ret, nil
+}
+
+func splitBlock(b Block) ([]Block, error) {
+	var err error
+
+	if p := textProperty(&b); *p != nil {
+		prop := **p
+		if prop.Children, err = SplitBlocks(prop.Children); err != nil {
+			return nil, err
+		}
+		prop.RichText = api.SplitRichText(prop.RichText)
+		prop.Text = api.SplitRichText(prop.Text)
+		if err := checkElements("text", prop.Text); err != nil {
+			return nil, err
+		}
+
+		return spread(b, prop.RichText, func(b *Block, rts []api.RichText, first bool) {
+			part := prop
+			part.RichText = rts
+			if !first {
+				part.Children, part.Checked, part.Icon = nil, false, nil
+			}
+			*textProperty(b) = &part
+		}), nil
+	}
+
+	if b.Code != nil {
+		code := *b.Code
+		code.Caption = api.SplitRichText(code.Caption)
+		if err := checkElements("caption", code.Caption); err != nil {
+			return nil, err
+		}
+
+		return spread(b, api.SplitRichText(code.RichText), func(b *Block, rts []api.RichText, first bool) {
+			part := code
+			part.RichText = rts
+			if !first {
+				part.Caption = nil
+			}
+			b.Code = &part
+		}), nil
+	}
+
+	if b.Template != nil {
+		tmpl := *b.Template
+		if tmpl.Children, err = SplitBlocks(tmpl.Children); err != nil {
+			return nil, err
+		}
+
+		return spread(b, api.SplitRichText(tmpl.RichText), func(b *Block, rts []api.RichText, first bool) {
+			part := tmpl
+			part.RichText = rts
+			if !first {
+				part.Children = nil
+			}
+			b.Template = &part
+		}), nil
+	}
+
+	for _, fb := range []**FileBlock{&b.Image, &b.Video, &b.Audio, &b.File, &b.PDF} {
+		if *fb != nil {
+			f := **fb
+			f.Caption = api.SplitRichText(f.Caption)
+			if err := checkElements("caption", f.Caption); err != nil {
+				return nil, err
+			}
+			*fb = &f
+		}
+	}
+
+	switch {
+	case b.Bookmark != nil:
+		bm := *b.Bookmark
+		bm.Caption = api.SplitRichText(bm.Caption)
+		err = checkElements("caption", bm.Caption)
+		b.Bookmark = &bm
+	case b.Embed != nil:
+		em := *b.Embed
+		em.Caption = api.SplitRichText(em.Caption)
+		err = checkElements("caption", em.Caption)
+		b.Embed = &em
+	case b.TableRow != nil:
+		row := TableRow{Cells: make([][]api.RichText, len(b.TableRow.Cells))}
+		for i, cell := range b.TableRow.Cells {
+			row.Cells[i] = api.SplitRichText(cell)
+			if err = checkElements(fmt.Sprintf("cell %d", i), row.Cells[i]); err != nil {
+				break
+			}
+		}
+		b.TableRow = &row
+	case b.Table != nil:
+		table := *b.Table
+		table.Children, err = SplitBlocks(table.Children)
+		b.Table = &table
+	case b.ColumnList != nil:
+		cl := *b.ColumnList
+		cl.Children, err = SplitBlocks(cl.Children)
+		b.ColumnList = &cl
+	case b.Column != nil:
+		col := *b.Column
+		col.Children, err = SplitBlocks(col.Children)
+		b.Column = &col
+	case b.SyncedBlock != nil:
+		sb := *b.SyncedBlock
+		sb.Children, err = SplitBlocks(sb.Children)
+		b.SyncedBlock = &sb
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []Block{b}, nil
+}
+
+// spread returns b, or copies of b when rts has more than api.MaxRichTextElements runs, each set
+// with a chunk of rts.
+func spread(b Block, rts []api.RichText, set func(b *Block, rts []api.RichText, first bool)) []Block {
+	var ret []Block
+	for start := 0; start == 0 || start < len(rts); start += api.MaxRichTextElements {
+		end := start + api.MaxRichTextElements
+		if end > len(rts) {
+			end = len(rts)
+		}
+
+		part := b
+		set(&part, rts[start:end:end], start == 0)
+		ret = append(ret, part)
+	}
+
+	return ret
+}
+
+// textProperty returns the field holding the content of a text-like block.
+func textProperty(b *Block) **Property {
+	for _, p := range []**Property{
+		&b.Paragraph, &b.Heading1, &b.Heading2, &b.Heading3, &b.BulletedListItem, &b.NumberedListItem,
+		&b.Todo, &b.Toggle, &b.Quote, &b.Callout,
+	} {
+		if *p != nil {
+			return p
+		}
+	}
+
+	return &b.Paragraph
+}
+
+func checkElements(what string, rts []api.RichText) error {
+	if len(rts) > api.MaxRichTextElements {
+		return fmt.Errorf("%s has %d rich text elements, the limit is %d", what, len(rts), api.MaxRichTextElements)
+	}
+
+	return nil
+}
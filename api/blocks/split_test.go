@@ -0,0 +1,108 @@
+package blocks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestSplitBlocks(t *testing.T) {
+	var runs []api.RichText
+	for i := 0; i < 150; i++ {
+		runs = append(runs, api.NewText("x"), api.NewUserMention("user-1"))
+	}
+
+	child := Block{Type: &BTParagraph, Paragraph: &Property{RichText: api.NewTexts("child")}}
+	in := []Block{
+		{Type: &BTToggle, Toggle: &Property{RichText: runs, Color: "red", Children: []Block{child}}},
+		{Type: &BTCode, Code: &CodeBlock{RichText: []api.RichText{api.NewText(strings.Repeat("c", 4500))}, Language: "go"}},
+		{Type: &BTColumnList, ColumnList: &ColumnList{Children: []Block{
+			{Type: &BTColumn, Column: &Column{Children: []Block{
+				{Type: &BTQuote, Quote: &Property{RichText: []api.RichText{api.NewText(strings.Repeat("q", 2001))}}},
+			}}},
+		}}},
+		{Type: &BTDivider, Divider: &Divider{}},
+	}
+
+	got, err := SplitBlocks(in)
+	if err != nil {
+		t.Fatalf("SplitBlocks failed: %v", err)
+	}
+
+	if len(got) != 6 {
+		t.Fatalf("expected 6 blocks, got %d", len(got))
+	}
+	for i, b := range got[:3] {
+		if b.Toggle == nil || b.Toggle.Color != "red" {
+			t.Fatalf("block %d: expected a red toggle, got %+v", i, b)
+		}
+	}
+	if n := len(got[0].Toggle.RichText) + len(got[1].Toggle.RichText) + len(got[2].Toggle.RichText); n != 300 {
+		t.Errorf("expected 300 runs over the toggles, got %d", n)
+	}
+	if len(got[0].Toggle.Children) != 1 || got[1].Toggle.Children != nil {
+		t.Error("expected only the first toggle to keep the children")
+	}
+	if got[3].Code == nil || len(got[3].Code.RichText) != 3 || got[3].Code.Language != "go" {
+		t.Errorf("unexpected code block %+v", got[3].Code)
+	}
+	quote := got[4].ColumnList.Children[0].Column.Children[0].Quote
+	if len(quote.RichText) != 2 {
+		t.Errorf("expected nested blocks to be split, got %d runs", len(quote.RichText))
+	}
+	if got[5].Divider == nil {
+		t.Error("expected the divider to be kept")
+	}
+
+	if len(in[0].Toggle.RichText) != 300 || len(in[2].ColumnList.Children[0].Column.Children[0].Quote.RichText) != 1 {
+		t.Error("expected the input not to be modified")
+	}
+}
+
+func TestSplitBlocks_FirstPartOnly(t *testing.T) {
+	var runs []api.RichText
+	for i := 0; i < 150; i++ {
+		runs = append(runs, api.NewText("x"), api.NewUserMention("user-1"))
+	}
+
+	got, err := SplitBlocks([]Block{
+		{Type: &BTTodo, Todo: &Property{RichText: runs, Checked: true}},
+		{Type: &BTCallout, Callout: &Property{RichText: runs, Icon: api.NewEmojiIcon("💡")}},
+		{Type: &BTCode, Code: &CodeBlock{RichText: runs, Caption: api.NewTexts("main.go"), Language: "go"}},
+	})
+	if err != nil {
+		t.Fatalf("SplitBlocks failed: %v", err)
+	}
+	if len(got) != 9 {
+		t.Fatalf("expected 9 blocks, got %d", len(got))
+	}
+
+	if !got[0].Todo.Checked || got[1].Todo.Checked || got[2].Todo.Checked {
+		t.Error("expected only the first to-do to be checked")
+	}
+	if got[3].Callout.Icon == nil || got[4].Callout.Icon != nil || got[5].Callout.Icon != nil {
+		t.Error("expected only the first callout to keep the icon")
+	}
+	if len(got[6].Code.Caption) != 1 || got[7].Code.Caption != nil || got[8].Code.Caption != nil {
+		t.Error("expected only the first code block to keep the caption")
+	}
+	if got[8].Code.Language != "go" {
+		t.Errorf("expected every code block to keep the language, got %q", got[8].Code.Language)
+	}
+}
+
+func TestSplitBlocks_Errors(t *testing.T) {
+	var runs []api.RichText
+	for i := 0; i < 101; i++ {
+		runs = append(runs, api.NewEquation("x"))
+	}
+
+	_, err := SplitBlocks([]Block{
+		{Type: &BTDivider, Divider: &Divider{}},
+		{Type: &BTImage, Image: &FileBlock{Caption: runs}},
+	})
+	if err == nil || err.Error() != "block 1: caption has 101 rich text elements, the limit is 100" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
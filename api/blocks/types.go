@@ -1,6 +1,11 @@
 package blocks
 
-import "github.com/surajssd/libnotion/api"
+import (
+	"encoding/json"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/internal/rawjson"
+)
 
 // PageResponseList is used to parse the response when querying pages endpoint.
 type BlockResponseList struct {
@@ -62,40 +67,87 @@ type Block struct {
 
 	// Template block
 	Template *TemplateBlock `json:"template,omitempty"`
-}
 
-type Property struct {
-	Text     []FullText `json:"text,omitempty"`
-	RichText []FullText `json:"rich_text,omitempty"`
-	Icon     *Icon      `json:"icon,omitempty"`
-	Checked  bool       `json:"checked,omitempty"`
-}
+	// Equation block
+	Equation *EquationBlock `json:"equation,omitempty"`
+
+	// Link to page block
+	LinkToPage *LinkToPage `json:"link_to_page,omitempty"`
 
-type Icon struct {
-	Type  string `json:"type,omitempty"`
-	Emoji string `json:"emoji,omitempty"`
+	// Meeting notes block. Blocks created before the rename are returned as "transcription".
+	MeetingNotes  *MeetingNotes `json:"meeting_notes,omitempty"`
+	Transcription *MeetingNotes `json:"transcription,omitempty"`
+
+	// Unsupported block. Returned for block types that the API does not support.
+	Unsupported *Unsupported `json:"unsupported,omitempty"`
+
+	// Fields of the block that this library does not know about, e.g. the content of block types
+	// added to Notion later. They are kept as is and written back when marshalled.
+	Unknown map[string]json.RawMessage `json:"-"`
 }
 
-type FullText struct {
-	Type        string       `json:"type,omitempty"`
-	Text        *Text        `json:"text,omitempty"`
-	Annotations *Annotations `json:"annotations,omitempty"`
-	PlainText   string       `json:"plain_text,omitempty"`
+// UnmarshalJSON decodes the block and keeps the fields it does not know about.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	type block Block
+	var bl block
+	if err := json.Unmarshal(data, &bl); err != nil {
+		return err
+	}
+
+	unknown, err := rawjson.Unknown(data, bl)
+	if err != nil {
+		return err
+	}
+
+	*b = Block(bl)
+	b.Unknown = unknown
+
+	return nil
 }
 
-type Text struct {
-	Content string    `json:"content,omitempty"`
-	Link    *api.Link `json:"link,omitempty"`
+// MarshalJSON encodes the block including the fields it does not know about.
+func (b Block) MarshalJSON() ([]byte, error) {
+	type block Block
+	data, err := json.Marshal(block(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return rawjson.Merge(data, b.Unknown)
 }
-type Annotations struct {
-	Bold          bool   `json:"bold,omitempty"`
-	Italic        bool   `json:"italic,omitempty"`
-	Strikethrough bool   `json:"strikethrough,omitempty"`
-	Underline     bool   `json:"underline,omitempty"`
-	Code          bool   `json:"code,omitempty"`
-	Color         string `json:"color,omitempty"`
+
+// Property holds the content of the text-like blocks: paragraphs, headings, list items, to-dos,
+// toggles, quotes and callouts.
+type Property struct {
+	Text     []api.RichText `json:"text,omitempty"`
+	RichText []api.RichText `json:"rich_text,omitempty"`
+	Icon     *Icon          `json:"icon,omitempty"`
+	Checked  bool           `json:"checked,omitempty"`
+
+	// Color of the block. See api.Color for the possible values.
+	Color string `json:"color,omitempty"`
+
+	// Whether a heading block can be collapsed like a toggle.
+	IsToggleable bool `json:"is_toggleable,omitempty"`
+
+	// Nested child blocks. Only used when creating blocks, Notion returns HasChildren instead.
+	Children []Block `json:"children,omitempty"`
 }
 
+// Icon is the icon of a callout block. Can be an emoji, an external image or a file.
+type Icon = api.Icon
+
+// FullText is a run of rich text.
+//
+// Deprecated: Use api.RichText, FullText is the same type.
+type FullText = api.RichText
+
+// Text is the content of a text rich text run.
+type Text = api.Text
+
+// Annotations is the styling of a rich text run.
+type Annotations = api.Annotation
+
 // BlockType
 type BT string
 
@@ -139,37 +191,41 @@ var (
 	BTTableRow = BT("table_row")
 	// Template block
 	BTTemplate = BT("template")
+	// Equation block
+	BTEquation = BT("equation")
+	// Link to page block
+	BTLinkToPage = BT("link_to_page")
+	// Meeting notes blocks
+	BTMeetingNotes  = BT("meeting_notes")
+	BTTranscription = BT("transcription")
 )
 
 // FileBlock represents media blocks (image, video, audio, file, pdf).
 type FileBlock struct {
-	Caption  []FullText  `json:"caption,omitempty"`
-	Type     string      `json:"type,omitempty"` // "external" or "file"
-	External *External   `json:"external,omitempty"`
-	File     *NotionFile `json:"file,omitempty"`
-	Name     string      `json:"name,omitempty"`
+	Caption    []api.RichText     `json:"caption,omitempty"`
+	Type       string             `json:"type,omitempty"` // "external", "file" or "file_upload"
+	External   *External          `json:"external,omitempty"`
+	File       *NotionFile        `json:"file,omitempty"`
+	FileUpload *api.FileUploadRef `json:"file_upload,omitempty"`
+	Name       string             `json:"name,omitempty"`
 }
 
 // External represents an externally hosted file.
-type External struct {
-	URL string `json:"url,omitempty"`
-}
+type External = api.External
 
 // NotionFile represents a file hosted by Notion.
-type NotionFile struct {
-	URL        string `json:"url,omitempty"`
-	ExpiryTime string `json:"expiry_time,omitempty"`
-}
+type NotionFile = api.NotionFile
 
 // BookmarkBlock represents a bookmark block.
 type BookmarkBlock struct {
-	Caption []FullText `json:"caption,omitempty"`
-	URL     string     `json:"url,omitempty"`
+	Caption []api.RichText `json:"caption,omitempty"`
+	URL     string         `json:"url,omitempty"`
 }
 
 // EmbedBlock represents an embed block.
 type EmbedBlock struct {
-	URL string `json:"url,omitempty"`
+	Caption []api.RichText `json:"caption,omitempty"`
+	URL     string         `json:"url,omitempty"`
 }
 
 // LinkPreview represents a link preview block.
@@ -179,9 +235,9 @@ type LinkPreview struct {
 
 // CodeBlock represents a code block.
 type CodeBlock struct {
-	Caption  []FullText `json:"caption,omitempty"`
-	RichText []FullText `json:"rich_text,omitempty"`
-	Language string     `json:"language,omitempty"`
+	Caption  []api.RichText `json:"caption,omitempty"`
+	RichText []api.RichText `json:"rich_text,omitempty"`
+	Language string         `json:"language,omitempty"`
 }
 
 // SyncedBlock represents a synced block.
@@ -232,15 +288,70 @@ type TableBlock struct {
 	TableWidth      int  `json:"table_width,omitempty"`
 	HasColumnHeader bool `json:"has_column_header,omitempty"`
 	HasRowHeader    bool `json:"has_row_header,omitempty"`
+
+	// Table rows. Only used when creating a table, which must be created with its rows.
+	Children []Block `json:"children,omitempty"`
 }
 
 // TableRow represents a table row block.
 type TableRow struct {
-	Cells [][]FullText `json:"cells,omitempty"`
+	Cells [][]api.RichText `json:"cells,omitempty"`
 }
 
 // TemplateBlock represents a template block.
 type TemplateBlock struct {
-	RichText []FullText `json:"rich_text,omitempty"`
-	Children []Block    `json:"children,omitempty"`
+	RichText []api.RichText `json:"rich_text,omitempty"`
+	Children []Block        `json:"children,omitempty"`
+}
+
+// EquationBlock represents a block-level KaTeX equation.
+type EquationBlock struct {
+	Expression string `json:"expression,omitempty"`
+}
+
+// LinkToPage represents a link to page block. Type is one of "page_id", "database_id" or
+// "comment_id" and the matching ID is set.
+type LinkToPage struct {
+	Type       string `json:"type,omitempty"`
+	PageID     string `json:"page_id,omitempty"`
+	DatabaseID string `json:"database_id,omitempty"`
+	CommentID  string `json:"comment_id,omitempty"`
+}
+
+// MeetingNotes represents a meeting notes block, with its AI summary, notes and transcript.
+type MeetingNotes struct {
+	Title []api.RichText `json:"title,omitempty"`
+
+	// Status of the meeting notes, e.g. "transcription_not_started", "transcription_paused",
+	// "transcription_in_progress", "summary_in_progress" or "notes_ready".
+	Status string `json:"status,omitempty"`
+
+	// IDs of the child blocks that hold the summary, notes and transcript.
+	Children *MeetingNotesChildren `json:"children,omitempty"`
+
+	CalendarEvent *CalendarEvent `json:"calendar_event,omitempty"`
+	Recording     *Recording     `json:"recording,omitempty"`
+}
+
+// MeetingNotesChildren points to the blocks that hold the content of a meeting notes block.
+type MeetingNotesChildren struct {
+	SummaryBlockID    string `json:"summary_block_id,omitempty"`
+	NotesBlockID      string `json:"notes_block_id,omitempty"`
+	TranscriptBlockID string `json:"transcript_block_id,omitempty"`
 }
+
+// CalendarEvent is the calendar event a meeting notes block was created for.
+type CalendarEvent struct {
+	StartTime string   `json:"start_time,omitempty"`
+	EndTime   string   `json:"end_time,omitempty"`
+	Attendees []string `json:"attendees,omitempty"`
+}
+
+// Recording is the time span of the recording of a meeting.
+type Recording struct {
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// Unsupported represents a block type that is not supported by the API.
+type Unsupported struct{}
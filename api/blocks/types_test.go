@@ -500,6 +500,257 @@ func TestBlock_Template(t *testing.T) {
 	}
 }
 
+func TestBlock_Equation(t *testing.T) {
+	bt := BTEquation
+	block := Block{Type: &bt, Equation: &EquationBlock{Expression: "e=mc^2"}}
+	got := jsonRoundTrip(t, block)
+	if got.Equation == nil || got.Equation.Expression != "e=mc^2" {
+		t.Errorf("unexpected Equation: %+v", got.Equation)
+	}
+}
+
+func TestBlock_LinkToPage(t *testing.T) {
+	bt := BTLinkToPage
+	block := Block{Type: &bt, LinkToPage: &LinkToPage{Type: "page_id", PageID: "page-1"}}
+	got := jsonRoundTrip(t, block)
+	if got.LinkToPage == nil || got.LinkToPage.Type != "page_id" || got.LinkToPage.PageID != "page-1" {
+		t.Errorf("unexpected LinkToPage: %+v", got.LinkToPage)
+	}
+
+	block = Block{Type: &bt, LinkToPage: &LinkToPage{Type: "database_id", DatabaseID: "db-1"}}
+	got = jsonRoundTrip(t, block)
+	if got.LinkToPage == nil || got.LinkToPage.DatabaseID != "db-1" {
+		t.Errorf("unexpected LinkToPage: %+v", got.LinkToPage)
+	}
+}
+
+func TestBlock_MeetingNotes(t *testing.T) {
+	data := []byte(`{
+		"object": "block",
+		"id": "b-1",
+		"type": "meeting_notes",
+		"meeting_notes": {
+			"title": [{"type": "text", "text": {"content": "Standup"}, "plain_text": "Standup"}],
+			"status": "notes_ready",
+			"children": {"summary_block_id": "s-1", "notes_block_id": "n-1", "transcript_block_id": "t-1"},
+			"calendar_event": {"start_time": "2025-01-01T09:00:00.000Z", "end_time": "2025-01-01T09:15:00.000Z", "attendees": ["user-1"]},
+			"recording": {"start_time": "2025-01-01T09:00:00.000Z", "end_time": "2025-01-01T09:14:00.000Z"}
+		}
+	}`)
+	var block Block
+	if err := json.Unmarshal(data, &block); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	got := jsonRoundTrip(t, block)
+	if got.Type == nil || *got.Type != BTMeetingNotes {
+		t.Errorf("expected type meeting_notes, got %v", got.Type)
+	}
+	mn := got.MeetingNotes
+	if mn == nil {
+		t.Fatal("expected MeetingNotes")
+	}
+	if len(mn.Title) != 1 || mn.Title[0].PlainText != "Standup" {
+		t.Errorf("unexpected Title: %+v", mn.Title)
+	}
+	if mn.Status != "notes_ready" {
+		t.Errorf("expected Status %q, got %q", "notes_ready", mn.Status)
+	}
+	if mn.Children == nil || mn.Children.TranscriptBlockID != "t-1" || mn.Children.SummaryBlockID != "s-1" {
+		t.Errorf("unexpected Children: %+v", mn.Children)
+	}
+	if mn.CalendarEvent == nil || len(mn.CalendarEvent.Attendees) != 1 {
+		t.Errorf("unexpected CalendarEvent: %+v", mn.CalendarEvent)
+	}
+	if mn.Recording == nil || mn.Recording.EndTime != "2025-01-01T09:14:00.000Z" {
+		t.Errorf("unexpected Recording: %+v", mn.Recording)
+	}
+}
+
+func TestBlock_Transcription(t *testing.T) {
+	bt := BTTranscription
+	block := Block{Type: &bt, Transcription: &MeetingNotes{Status: "transcription_in_progress"}}
+	got := jsonRoundTrip(t, block)
+	if got.Transcription == nil || got.Transcription.Status != "transcription_in_progress" {
+		t.Errorf("unexpected Transcription: %+v", got.Transcription)
+	}
+}
+
+func TestBlock_Unsupported(t *testing.T) {
+	var block Block
+	if err := json.Unmarshal([]byte(`{"type":"unsupported","unsupported":{}}`), &block); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if block.Unsupported == nil {
+		t.Error("expected Unsupported")
+	}
+}
+
+func TestBlock_ToggleableHeading(t *testing.T) {
+	bt := BTHeading2
+	block := Block{
+		Type: &bt,
+		Heading2: &Property{
+			RichText:     []FullText{{PlainText: "Details"}},
+			IsToggleable: true,
+			Color:        "blue_background",
+		},
+	}
+	got := jsonRoundTrip(t, block)
+	if got.Heading2 == nil || !got.Heading2.IsToggleable {
+		t.Errorf("expected toggleable heading, got %+v", got.Heading2)
+	}
+	if got.Heading2.Color != "blue_background" {
+		t.Errorf("expected Color %q, got %q", "blue_background", got.Heading2.Color)
+	}
+}
+
+func TestBlock_Color(t *testing.T) {
+	bt := BTParagraph
+	block := Block{Type: &bt, Paragraph: &Property{RichText: []FullText{{PlainText: "Red"}}, Color: "red"}}
+	got := jsonRoundTrip(t, block)
+	if got.Paragraph == nil || got.Paragraph.Color != "red" {
+		t.Errorf("unexpected Paragraph: %+v", got.Paragraph)
+	}
+}
+
+func TestBlock_CalloutIcons(t *testing.T) {
+	tests := []struct {
+		name string
+		icon *Icon
+		url  func(*Icon) string
+	}{
+		{"external", &Icon{Type: "external", External: &External{URL: "https://example.com/i.png"}}, func(i *Icon) string { return i.External.URL }},
+		{"file", &Icon{Type: "file", File: &NotionFile{URL: "https://s3/i.png", ExpiryTime: "2025-01-01T00:00:00.000Z"}}, func(i *Icon) string { return i.File.URL }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bt := BTCallout
+			block := Block{Type: &bt, Callout: &Property{Icon: tt.icon}}
+			got := jsonRoundTrip(t, block)
+			if got.Callout == nil || got.Callout.Icon == nil || got.Callout.Icon.Type != tt.name {
+				t.Fatalf("unexpected Icon: %+v", got.Callout)
+			}
+			if tt.url(got.Callout.Icon) != tt.url(tt.icon) {
+				t.Errorf("expected URL %q, got %q", tt.url(tt.icon), tt.url(got.Callout.Icon))
+			}
+		})
+	}
+}
+
+func TestBlock_ListItemChildren(t *testing.T) {
+	bt := BTBulletListItem
+	child := BTParagraph
+	block := Block{
+		Type: &bt,
+		BulletedListItem: &Property{
+			RichText: []FullText{{PlainText: "Parent"}},
+			Children: []Block{{Type: &child, Paragraph: &Property{RichText: []FullText{{PlainText: "Child"}}}}},
+		},
+	}
+	got := jsonRoundTrip(t, block)
+	if got.BulletedListItem == nil || len(got.BulletedListItem.Children) != 1 {
+		t.Fatalf("unexpected BulletedListItem: %+v", got.BulletedListItem)
+	}
+	if got.BulletedListItem.Children[0].Paragraph.RichText[0].PlainText != "Child" {
+		t.Errorf("unexpected child: %+v", got.BulletedListItem.Children[0])
+	}
+}
+
+func TestBlock_TableChildren(t *testing.T) {
+	bt := BTTable
+	row := BTTableRow
+	block := Block{
+		Type: &bt,
+		Table: &TableBlock{
+			TableWidth: 1,
+			Children:   []Block{{Type: &row, TableRow: &TableRow{Cells: [][]FullText{{{PlainText: "A1"}}}}}},
+		},
+	}
+	got := jsonRoundTrip(t, block)
+	if got.Table == nil || len(got.Table.Children) != 1 || got.Table.Children[0].TableRow == nil {
+		t.Errorf("unexpected Table: %+v", got.Table)
+	}
+}
+
+func TestBlock_EmbedCaption(t *testing.T) {
+	bt := BTEmbed
+	block := Block{Type: &bt, Embed: &EmbedBlock{URL: "https://example.com", Caption: []FullText{{PlainText: "Embedded"}}}}
+	got := jsonRoundTrip(t, block)
+	if got.Embed == nil || len(got.Embed.Caption) != 1 {
+		t.Errorf("unexpected Embed: %+v", got.Embed)
+	}
+}
+
+func TestBlock_UnknownType(t *testing.T) {
+	data := `{"has_children":true,"id":"b-1","object":"block","shiny_new_block":{"rich_text":[],"level":2},"type":"shiny_new_block"}`
+	var block Block
+	if err := json.Unmarshal([]byte(data), &block); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if block.Type == nil || *block.Type != BT("shiny_new_block") {
+		t.Errorf("unexpected Type: %v", block.Type)
+	}
+	if string(block.Unknown["shiny_new_block"]) != `{"rich_text":[],"level":2}` {
+		t.Errorf("unexpected Unknown: %v", block.Unknown)
+	}
+	if _, ok := block.Unknown["has_children"]; ok {
+		t.Error("expected has_children to be a known field")
+	}
+	got, err := json.Marshal(block)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("round trip mismatch:\nwant %s\ngot  %s", data, got)
+	}
+}
+
+func TestBlockResponseList_UnknownFields(t *testing.T) {
+	data := []byte(`{"object":"list","results":[{"id":"b-1","type":"tab","tab":{"title":"One"}}]}`)
+	var list BlockResponseList
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if len(list.Results) != 1 || string(list.Results[0].Unknown["tab"]) != `{"title":"One"}` {
+		t.Errorf("unexpected results: %+v", list.Results)
+	}
+}
+
+func TestBlock_RichTextMentionsAndHref(t *testing.T) {
+	data := []byte(`{
+		"type": "paragraph",
+		"paragraph": {
+			"rich_text": [
+				{"type": "text", "text": {"content": "See ", "link": null}, "plain_text": "See "},
+				{"type": "mention", "mention": {"type": "user", "user": {"object": "user", "id": "user-1"}}, "plain_text": "@Ada"},
+				{"type": "text", "text": {"content": "docs", "link": {"url": "https://example.com"}}, "plain_text": "docs", "href": "https://example.com"},
+				{"type": "equation", "equation": {"expression": "a+b"}, "plain_text": "a+b"}
+			]
+		}
+	}`)
+	var block Block
+	if err := json.Unmarshal(data, &block); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	got := jsonRoundTrip(t, block)
+	rts := got.Paragraph.RichText
+	if len(rts) != 4 {
+		t.Fatalf("expected 4 runs, got %d", len(rts))
+	}
+	if rts[1].Mention == nil || rts[1].Mention.User == nil || rts[1].Mention.User.ID != "user-1" {
+		t.Errorf("unexpected mention: %+v", rts[1].Mention)
+	}
+	if rts[2].Href != "https://example.com" {
+		t.Errorf("expected Href %q, got %q", "https://example.com", rts[2].Href)
+	}
+	if rts[3].Equation == nil || rts[3].Equation.Expression != "a+b" {
+		t.Errorf("unexpected equation: %+v", rts[3].Equation)
+	}
+	if txt := api.PlainText(rts); txt != "See @Adadocsa+b" {
+		t.Errorf("unexpected plain text: %q", txt)
+	}
+}
+
 func TestProperty_JSON(t *testing.T) {
 	prop := Property{
 		Text:     []FullText{{PlainText: "text content"}},
@@ -616,6 +867,17 @@ func TestFileBlock_External(t *testing.T) {
 	}
 }
 
+func TestFileBlock_FileUpload(t *testing.T) {
+	fb := FileBlock{
+		Type:       "file_upload",
+		FileUpload: &api.FileUploadRef{ID: "fu-1"},
+	}
+	got := jsonRoundTrip(t, fb)
+	if got.FileUpload == nil || got.FileUpload.ID != "fu-1" {
+		t.Errorf("unexpected FileUpload: %+v", got.FileUpload)
+	}
+}
+
 func TestCodeBlock_JSON(t *testing.T) {
 	cb := CodeBlock{
 		Language: "python",
@@ -704,14 +966,18 @@ func TestBlockTypeVariables(t *testing.T) {
 		"breadcrumb":         BTBreadcrumb,
 		"table_row":          BTTableRow,
 		"template":           BTTemplate,
+		"equation":           BTEquation,
+		"link_to_page":       BTLinkToPage,
+		"meeting_notes":      BTMeetingNotes,
+		"transcription":      BTTranscription,
 	}
 	for expected, got := range types {
 		if string(got) != expected {
 			t.Errorf("expected block type %q, got %q", expected, got)
 		}
 	}
-	// Verify count — 31 block types
-	if len(types) != 31 {
-		t.Errorf("expected 31 block types, got %d", len(types))
+	// Verify count — 35 block types
+	if len(types) != 35 {
+		t.Errorf("expected 35 block types, got %d", len(types))
 	}
 }
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"strings"
+)
+
+// EqualValues reports whether two values of a property are the same, as far as Notion stores them:
+// a value sent and the value Notion returns for it are equal although Notion adds IDs, plain text
+// and formatting defaults. Text is compared with its annotations and links, however it is split
+// into runs, options by name, or by ID when one has no name, relations and people by ID and dates
+// by the time they stand for. Values of different types are never equal.
+func EqualValues(a, b ValueProperty) bool {
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case ValuePropertyTypeTitle:
+		return equalTexts(a.Title, b.Title)
+	case ValuePropertyTypeRichText:
+		return equalTexts(a.RichText, b.RichText)
+	case ValuePropertyTypeNumber:
+		return (a.Number == nil && b.Number == nil) ||
+			(a.Number != nil && b.Number != nil && *a.Number == *b.Number)
+	case ValuePropertyTypeCheckbox:
+		return a.Checkbox == b.Checkbox
+	case ValuePropertyTypeURL:
+		return a.URL == b.URL
+	case ValuePropertyTypeEmail:
+		return a.Email == b.Email
+	case ValuePropertyTypePhoneNumber:
+		return a.PhoneNumber == b.PhoneNumber
+	case ValuePropertyTypeSelect:
+		return equalOption(a.Select, b.Select)
+	case ValuePropertyTypeStatus:
+		return equalOption(a.Status, b.Status)
+	case ValuePropertyTypeMultiSelect:
+		return equalSlices(a.MultiSelect, b.MultiSelect, func(x, y Option) bool { return equalOption(&x, &y) })
+	case ValuePropertyTypeDate:
+		return equalDate(a.Date, b.Date)
+	case ValuePropertyTypeRelation:
+		return equalSlices(a.Relation, b.Relation, func(x, y Relation) bool { return equalID(x.ID, y.ID) })
+	case ValuePropertyTypePeople:
+		return equalSlices(a.People, b.People, func(x, y User) bool { return equalID(x.ID, y.ID) })
+	case ValuePropertyTypeFiles:
+		return equalSlices(a.Files, b.Files, equalFile)
+	}
+
+	ja, errA := a.MarshalJSON()
+	jb, errB := b.MarshalJSON()
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// textRun is a run of rich text as compared by EqualValues.
+type textRun struct {
+	kind        RichTextType
+	text        string
+	annotations Annotation
+	link        string
+}
+
+func equalTexts(a, b []RichText) bool {
+	return equalSlices(textRuns(a), textRuns(b), func(x, y textRun) bool { return x == y })
+}
+
+// textRuns returns the runs with the formatting defaults of Notion filled in, and adjacent text
+// runs with the same formatting joined, as Notion may split text differently than it was sent.
+func textRuns(rts []RichText) []textRun {
+	var ret []textRun
+	for _, rt := range rts {
+		run := textRun{kind: RichTextTypeText, text: rt.String()}
+		switch {
+		case rt.Mention != nil:
+			run.kind = RichTextTypeMention
+		case rt.Equation != nil:
+			run.kind = RichTextTypeEquation
+		case rt.Text != nil && rt.Text.Link != nil:
+			run.link = rt.Text.Link.URL
+		default:
+			run.link = rt.Href
+		}
+		if rt.Annotations != nil {
+			run.annotations = *rt.Annotations
+		}
+		if run.annotations.Color == "" {
+			run.annotations.Color = ColorDefault
+		}
+
+		if n := len(ret); n > 0 && run.kind == RichTextTypeText && ret[n-1].kind == RichTextTypeText &&
+			ret[n-1].annotations == run.annotations && ret[n-1].link == run.link {
+			ret[n-1].text += run.text
+			continue
+		}
+		ret = append(ret, run)
+	}
+
+	return ret
+}
+
+func equalOption(a, b *Option) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Name == "" || b.Name == "" {
+		return a.ID != "" && a.ID == b.ID
+	}
+
+	return a.Name == b.Name
+}
+
+func equalDate(a, b *DateRange) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return equalDateString(a.Start, a.TimeZone, b.Start, b.TimeZone) &&
+		equalDateString(a.End, a.TimeZone, b.End, b.TimeZone)
+}
+
+func equalDateString(a, tzA, b, tzB string) bool {
+	if a == b {
+		return true
+	}
+
+	ta, timeA, errA := ParseDate(a, tzA)
+	tb, timeB, errB := ParseDate(b, tzB)
+	return errA == nil && errB == nil && timeA == timeB && ta.Equal(tb)
+}
+
+// equalID compares Notion IDs, which are returned with dashes but can be sent without.
+func equalID(a, b string) bool {
+	return strings.ReplaceAll(a, "-", "") == strings.ReplaceAll(b, "-", "")
+}
+
+// equalFile compares external files by URL and files hosted by Notion by name. A file uploaded
+// with the File Upload API is only equal to the same upload.
+func equalFile(a, b File) bool {
+	switch {
+	case a.External != nil || b.External != nil:
+		return a.External != nil && b.External != nil && a.External.URL == b.External.URL
+	case a.FileUpload != nil || b.FileUpload != nil:
+		return a.FileUpload != nil && b.FileUpload != nil && a.FileUpload.ID == b.FileUpload.ID
+	}
+
+	return a.Name == b.Name
+}
+
+func equalSlices[T any](a, b []T, eq func(x, y T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
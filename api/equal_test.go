@@ -0,0 +1,127 @@
+package api
+
+import "testing"
+
+func TestEqualValues(t *testing.T) {
+	returnedTitle := ValueProperty{Type: ValuePropertyTypeTitle, Title: []RichText{{
+		Type:        RichTextTypeText,
+		Text:        &Text{Content: "Dune"},
+		Annotations: &Annotation{Color: ColorDefault},
+		PlainText:   "Dune",
+	}}}
+
+	tests := []struct {
+		name string
+		a, b ValueProperty
+		want bool
+	}{
+		{"title as returned", ValueProperty{Type: ValuePropertyTypeTitle, Title: NewTexts("Dune")}, returnedTitle, true},
+		{"title changed", ValueProperty{Type: ValuePropertyTypeTitle, Title: NewTexts("Dune II")}, returnedTitle, false},
+		{
+			"title split differently",
+			ValueProperty{Type: ValuePropertyTypeTitle, Title: []RichText{NewText("Du"), NewText("ne")}},
+			returnedTitle,
+			true,
+		},
+		{
+			"title made bold",
+			ValueProperty{Type: ValuePropertyTypeTitle, Title: []RichText{{Type: RichTextTypeText, Text: &Text{Content: "Dune"}, Annotations: &Annotation{Bold: true}}}},
+			returnedTitle,
+			false,
+		},
+		{
+			"link as returned",
+			ValueProperty{Type: ValuePropertyTypeRichText, RichText: []RichText{NewLink("Dune", "https://dune")}},
+			ValueProperty{Type: ValuePropertyTypeRichText, RichText: []RichText{{
+				Type:        RichTextTypeText,
+				Text:        &Text{Content: "Dune", Link: &Link{URL: "https://dune"}},
+				Annotations: &Annotation{Color: ColorDefault},
+				PlainText:   "Dune",
+				Href:        "https://dune",
+			}}},
+			true,
+		},
+		{
+			"link removed",
+			ValueProperty{Type: ValuePropertyTypeRichText, RichText: NewTexts("Dune")},
+			ValueProperty{Type: ValuePropertyTypeRichText, RichText: []RichText{NewLink("Dune", "https://dune")}},
+			false,
+		},
+		{"different types", ValueProperty{Type: ValuePropertyTypeRichText, RichText: NewTexts("Dune")}, returnedTitle, false},
+		{"number", ValueProperty{Type: ValuePropertyTypeNumber, Number: Ptr(3.0)}, ValueProperty{Type: ValuePropertyTypeNumber, Number: Ptr(3.0)}, true},
+		{"number cleared", ValueProperty{Type: ValuePropertyTypeNumber}, ValueProperty{Type: ValuePropertyTypeNumber, Number: Ptr(0.0)}, false},
+		{
+			"select by name",
+			ValueProperty{Type: ValuePropertyTypeSelect, Select: &Option{Name: "Fiction"}},
+			ValueProperty{Type: ValuePropertyTypeSelect, Select: &Option{ID: "abc", Name: "Fiction", Color: "red"}},
+			true,
+		},
+		{
+			"select by ID",
+			ValueProperty{Type: ValuePropertyTypeSelect, Select: &Option{ID: "abc"}},
+			ValueProperty{Type: ValuePropertyTypeSelect, Select: &Option{ID: "abc", Name: "Fiction"}},
+			true,
+		},
+		{
+			"multi select order",
+			ValueProperty{Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "a"}, {Name: "b"}}},
+			ValueProperty{Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "b"}, {Name: "a"}}},
+			false,
+		},
+		{
+			"date time zones",
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02T10:00:00Z"}},
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02T12:00:00.000+02:00"}},
+			true,
+		},
+		{
+			"date and date time",
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02"}},
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02T00:00:00.000+00:00"}},
+			false,
+		},
+		{
+			"date end added",
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02", End: "2024-01-03"}},
+			ValueProperty{Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2024-01-02"}},
+			false,
+		},
+		{
+			"relation dashes",
+			ValueProperty{Type: ValuePropertyTypeRelation, Relation: []Relation{{ID: "1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f"}}},
+			ValueProperty{Type: ValuePropertyTypeRelation, Relation: []Relation{{ID: "1c2d3e4f-5a6b-7c8d-9e0f-1a2b3c4d5e6f"}}},
+			true,
+		},
+		{
+			"people",
+			ValueProperty{Type: ValuePropertyTypePeople, People: []User{{ID: "u-1"}}},
+			ValueProperty{Type: ValuePropertyTypePeople, People: []User{{ID: "u-1", Name: "Ann"}, {ID: "u-2"}}},
+			false,
+		},
+		{
+			"external file",
+			ValueProperty{Type: ValuePropertyTypeFiles, Files: []File{{Name: "a", Type: "external", External: &External{URL: "https://a"}}}},
+			ValueProperty{Type: ValuePropertyTypeFiles, Files: []File{{Name: "a", Type: "external", External: &External{URL: "https://a"}}}},
+			true,
+		},
+		{
+			"uploaded file",
+			ValueProperty{Type: ValuePropertyTypeFiles, Files: []File{{Name: "a", FileUpload: &FileUploadRef{ID: "up-1"}}}},
+			ValueProperty{Type: ValuePropertyTypeFiles, Files: []File{{Name: "a", Type: "file", File: &NotionFile{URL: "https://s3/a"}}}},
+			false,
+		},
+		{"checkbox", ValueProperty{Type: ValuePropertyTypeCheckbox}, ValueProperty{Type: ValuePropertyTypeCheckbox, Checkbox: true}, false},
+		{"email", ValueProperty{Type: ValuePropertyTypeEmail, Email: "a@b.c"}, ValueProperty{Type: ValuePropertyTypeEmail, Email: "a@b.c"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualValues(tt.a, tt.b); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if got := EqualValues(tt.b, tt.a); got != tt.want {
+				t.Errorf("expected %v with the values swapped, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxFilterDepth is how deep compound filters can be nested. A top level "and" can hold an "or",
+// but that "or" can only hold property and timestamp conditions.
+const maxFilterDepth = 2
+
+// Validate checks the filter against the rules Notion applies to query filters, so that mistakes
+// are reported before making a request. It checks that every node is either a compound filter or a
+// single condition, that compound filters are at most two levels deep, that every condition has
+// exactly one operator and that dates are valid.
+func (f PropertyFilter) Validate() error {
+	return f.validate("filter", 0, false)
+}
+
+func (f PropertyFilter) validate(path string, depth int, inRollup bool) error {
+	conds := setFields(f, "or", "and", "property", "timestamp")
+
+	if len(f.Or) > 0 || len(f.And) > 0 {
+		switch {
+		case inRollup:
+			return fmt.Errorf("%s: rollup conditions cannot be compound filters", path)
+		case len(f.Or) > 0 && len(f.And) > 0:
+			return fmt.Errorf("%s: filter has both \"or\" and \"and\", nest one inside the other", path)
+		case f.Property != "" || f.Timestamp != "" || len(conds) > 0:
+			return fmt.Errorf("%s: compound filter cannot also have a condition", path)
+		case depth >= maxFilterDepth:
+			return fmt.Errorf("%s: compound filters can only be nested %d levels deep", path, maxFilterDepth)
+		}
+
+		op, children := "or", f.Or
+		if len(f.And) > 0 {
+			op, children = "and", f.And
+		}
+
+		for i, c := range children {
+			if err := c.validate(fmt.Sprintf("%s.%s[%d]", path, op, i), depth+1, false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch {
+	case len(conds) == 0:
+		return fmt.Errorf("%s: filter has no condition", path)
+	case len(conds) > 1:
+		return fmt.Errorf("%s: filter has more than one condition: %s", path, strings.Join(conds, ", "))
+	}
+
+	switch {
+	case f.Timestamp != "":
+		if f.Property != "" {
+			return fmt.Errorf("%s: filter has both a property and a timestamp", path)
+		}
+		if string(f.Timestamp) != conds[0] ||
+			(f.Timestamp != FilterTimestampCreatedTime && f.Timestamp != FilterTimestampLastEditedTime) {
+			return fmt.Errorf("%s: timestamp %q needs a %q condition, got %q", path, f.Timestamp, f.Timestamp, conds[0])
+		}
+	case inRollup:
+		if f.Property != "" {
+			return fmt.Errorf("%s: rollup conditions cannot have a property", path)
+		}
+	case f.Property == "":
+		return fmt.Errorf("%s: filter has no property", path)
+	}
+
+	path += "." + conds[0]
+
+	switch {
+	case f.Formula != nil:
+		return validateFormulaFilter(path, *f.Formula)
+	case f.Rollup != nil:
+		return validateRollupFilter(path, *f.Rollup)
+	case f.Date != nil:
+		return validateDateFilter(path, *f.Date)
+	case f.CreatedTime != nil:
+		return validateDateFilter(path, *f.CreatedTime)
+	case f.LastEditedTime != nil:
+		return validateDateFilter(path, *f.LastEditedTime)
+	}
+
+	return validateOperator(path, reflect.ValueOf(f).FieldByIndex(conditionIndex(conds[0])).Elem().Interface())
+}
+
+func validateFormulaFilter(path string, f FormulaFilter) error {
+	conds := setFields(f)
+	if len(conds) != 1 {
+		return fmt.Errorf("%s: formula filter needs exactly one of string, checkbox, number or date", path)
+	}
+
+	path += "." + conds[0]
+
+	switch {
+	case f.String != nil:
+		return validateOperator(path, *f.String)
+	case f.Checkbox != nil:
+		return validateOperator(path, *f.Checkbox)
+	case f.Number != nil:
+		return validateOperator(path, *f.Number)
+	default:
+		return validateDateFilter(path, *f.Date)
+	}
+}
+
+func validateRollupFilter(path string, f RollupFilter) error {
+	conds := setFields(f)
+	if len(conds) != 1 {
+		return fmt.Errorf("%s: rollup filter needs exactly one of any, every, none, number or date", path)
+	}
+
+	path += "." + conds[0]
+
+	switch {
+	case f.Any != nil:
+		return f.Any.validate(path, maxFilterDepth, true)
+	case f.Every != nil:
+		return f.Every.validate(path, maxFilterDepth, true)
+	case f.None != nil:
+		return f.None.validate(path, maxFilterDepth, true)
+	case f.Number != nil:
+		return validateOperator(path, *f.Number)
+	default:
+		return validateDateFilter(path, *f.Date)
+	}
+}
+
+func validateDateFilter(path string, f DateFilter) error {
+	if err := validateOperator(path, f); err != nil {
+		return err
+	}
+
+	for _, d := range []string{f.Equals, f.Before, f.After, f.OnOrBefore, deref(f.OnOrAfter)} {
+		if d == "" {
+			continue
+		}
+
+		if _, _, err := ParseDate(d, ""); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if f.OnOrAfter != nil && *f.OnOrAfter == "" {
+		return fmt.Errorf("%s: on_or_after needs a date", path)
+	}
+
+	return nil
+}
+
+// validateOperator checks that exactly one operator of the condition is set.
+func validateOperator(path string, cond interface{}) error {
+	ops := setFields(cond)
+
+	switch {
+	case len(ops) == 0:
+		return fmt.Errorf("%s: condition has no operator", path)
+	case len(ops) > 1:
+		return fmt.Errorf("%s: condition has more than one operator: %s", path, strings.Join(ops, ", "))
+	}
+
+	return nil
+}
+
+// setFields returns the JSON names of the fields of the struct that are set, leaving out the
+// fields named in skip.
+func setFields(v interface{}, skip ...string) []string {
+	var ret []string
+
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		name := jsonName(rt.Field(i))
+		if contains(skip, name) || rv.Field(i).IsZero() {
+			continue
+		}
+
+		ret = append(ret, name)
+	}
+
+	return ret
+}
+
+// conditionIndex returns the index of the PropertyFilter field with the given JSON name.
+func conditionIndex(name string) []int {
+	rt := reflect.TypeOf(PropertyFilter{})
+	for i := 0; i < rt.NumField(); i++ {
+		if jsonName(rt.Field(i)) == name {
+			return rt.Field(i).Index
+		}
+	}
+
+	panic(fmt.Sprintf("no filter condition %q", name))
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
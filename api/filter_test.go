@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFilter_NestedJSON(t *testing.T) {
+	filter := Filter{
+		And: []PropertyFilter{
+			{Property: "Done", Checkbox: &CheckboxFilter{Equals: &BoolTrue}},
+			{Or: []PropertyFilter{
+				{Property: "Score", Number: &NumberFilter{GreaterThan: Ptr(2.5)}},
+				{Timestamp: FilterTimestampCreatedTime, CreatedTime: &DateFilter{PastWeek: &EmptyConfig{}}},
+				{Property: "Phone", Phone: &TextFilter{IsEmpty: true}},
+			}},
+		},
+	}
+
+	b, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	want := `{"and":[` +
+		`{"property":"Done","checkbox":{"equals":true}},` +
+		`{"or":[` +
+		`{"property":"Score","number":{"greater_than":2.5}},` +
+		`{"timestamp":"created_time","created_time":{"past_week":{}}},` +
+		`{"property":"Phone","phone_number":{"is_empty":true}}` +
+		`]}]}`
+	if string(b) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b)
+	}
+
+	got := jsonRoundTrip(t, filter)
+	if len(got.And) != 2 || len(got.And[1].Or) != 3 {
+		t.Fatalf("unexpected nesting: %+v", got)
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("expected round tripped filter to be valid, got %v", err)
+	}
+}
+
+func TestFilter_NewConditionsJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter PropertyFilter
+		want   string
+	}{
+		{
+			"relation",
+			PropertyFilter{Property: "Project", Relation: &RelationFilter{Contains: "page-1"}},
+			`{"property":"Project","relation":{"contains":"page-1"}}`,
+		},
+		{
+			"people",
+			PropertyFilter{Property: "Owner", People: &PeopleFilter{DoesNotContain: "user-1"}},
+			`{"property":"Owner","people":{"does_not_contain":"user-1"}}`,
+		},
+		{
+			"created by",
+			PropertyFilter{Property: "Author", CreatedBy: &PeopleFilter{Contains: "user-1"}},
+			`{"property":"Author","created_by":{"contains":"user-1"}}`,
+		},
+		{
+			"files",
+			PropertyFilter{Property: "Attachments", Files: &FilesFilter{IsNotEmpty: true}},
+			`{"property":"Attachments","files":{"is_not_empty":true}}`,
+		},
+		{
+			"formula",
+			PropertyFilter{Property: "Total", Formula: &FormulaFilter{Number: &NumberFilter{LessThan: Ptr(0.5)}}},
+			`{"property":"Total","formula":{"number":{"less_than":0.5}}}`,
+		},
+		{
+			"rollup any",
+			PropertyFilter{Property: "Tags", Rollup: &RollupFilter{Any: &PropertyFilter{RichText: &TextFilter{Contains: "x"}}}},
+			`{"property":"Tags","rollup":{"any":{"rich_text":{"contains":"x"}}}}`,
+		},
+		{
+			"unique id",
+			PropertyFilter{Property: "ID", UniqueID: &UniqueIDFilter{GreaterThanOrEqualTo: Ptr(0)}},
+			`{"property":"ID","unique_id":{"greater_than_or_equal_to":0}}`,
+		},
+		{
+			"verification",
+			PropertyFilter{Property: "Verification", Verification: &VerificationFilter{Status: VerificationStatusVerified}},
+			`{"property":"Verification","verification":{"status":"verified"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.filter)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, b)
+			}
+			if err := tt.filter.Validate(); err != nil {
+				t.Errorf("expected filter to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	leaf := PropertyFilter{Property: "Done", Checkbox: &CheckboxFilter{Equals: &BoolTrue}}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			filter:  Filter{},
+			wantErr: "filter: filter has no condition",
+		},
+		{
+			name:    "and with or",
+			filter:  Filter{And: []PropertyFilter{leaf}, Or: []PropertyFilter{leaf}},
+			wantErr: `filter has both "or" and "and"`,
+		},
+		{
+			name:    "compound with condition",
+			filter:  Filter{And: []PropertyFilter{leaf}, Property: "Done", Checkbox: &CheckboxFilter{Equals: &BoolTrue}},
+			wantErr: "compound filter cannot also have a condition",
+		},
+		{
+			name: "nested three levels",
+			filter: Filter{And: []PropertyFilter{
+				{Or: []PropertyFilter{
+					{And: []PropertyFilter{leaf}},
+				}},
+			}},
+			wantErr: "filter.and[0].or[0]: compound filters can only be nested 2 levels deep",
+		},
+		{
+			name:    "two conditions",
+			filter:  Filter{Property: "Name", Title: &TextFilter{Equals: "a"}, RichText: &TextFilter{Equals: "a"}},
+			wantErr: "filter has more than one condition: title, rich_text",
+		},
+		{
+			name:    "missing property",
+			filter:  Filter{Or: []PropertyFilter{{Title: &TextFilter{Equals: "a"}}}},
+			wantErr: "filter.or[0]: filter has no property",
+		},
+		{
+			name:    "two operators",
+			filter:  Filter{Property: "Score", Number: &NumberFilter{GreaterThan: Ptr(1.0), LessThan: Ptr(2.0)}},
+			wantErr: "filter.number: condition has more than one operator: greater_than, less_than",
+		},
+		{
+			name:    "no operator",
+			filter:  Filter{Property: "Name", Title: &TextFilter{}},
+			wantErr: "filter.title: condition has no operator",
+		},
+		{
+			name:    "timestamp mismatch",
+			filter:  Filter{Timestamp: FilterTimestampCreatedTime, LastEditedTime: &DateFilter{PastWeek: &EmptyConfig{}}},
+			wantErr: `timestamp "created_time" needs a "created_time" condition, got "last_edited_time"`,
+		},
+		{
+			name:    "timestamp with property",
+			filter:  Filter{Property: "Name", Timestamp: FilterTimestampCreatedTime, CreatedTime: &DateFilter{PastWeek: &EmptyConfig{}}},
+			wantErr: "filter has both a property and a timestamp",
+		},
+		{
+			name:    "invalid date",
+			filter:  Filter{Property: "Due", Date: &DateFilter{Before: "next tuesday"}},
+			wantErr: `filter.date: parsing date "next tuesday"`,
+		},
+		{
+			name:    "formula without type",
+			filter:  Filter{Property: "Total", Formula: &FormulaFilter{}},
+			wantErr: "formula filter needs exactly one of",
+		},
+		{
+			name:    "rollup with property",
+			filter:  Filter{Property: "Tags", Rollup: &RollupFilter{Every: &PropertyFilter{Property: "x", Select: &SelectFilter{Equals: "a"}}}},
+			wantErr: "filter.rollup.every: rollup conditions cannot have a property",
+		},
+		{
+			name:    "rollup with compound",
+			filter:  Filter{Property: "Tags", Rollup: &RollupFilter{None: &PropertyFilter{And: []PropertyFilter{leaf}}}},
+			wantErr: "rollup conditions cannot be compound filters",
+		},
+		{
+			name: "valid two levels",
+			filter: Filter{Or: []PropertyFilter{
+				{And: []PropertyFilter{leaf, {Property: "Due", Date: &DateFilter{OnOrAfter: Ptr("2021-01-01T10:00:00.000Z")}}}},
+				{Timestamp: FilterTimestampLastEditedTime, LastEditedTime: &DateFilter{After: "2021-01-01"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
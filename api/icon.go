@@ -0,0 +1,27 @@
+package api
+
+// NewEmojiIcon returns an icon showing the emoji, e.g. "📚".
+func NewEmojiIcon(emoji string) *Icon {
+	return &Icon{Type: "emoji", Emoji: emoji}
+}
+
+// NewExternalIcon returns an icon showing the image at the URL.
+func NewExternalIcon(url string) *Icon {
+	return &Icon{Type: "external", External: &External{URL: url}}
+}
+
+// NewFileUploadIcon returns an icon showing a file uploaded with the File Upload API, see
+// rest.UploadFile.
+func NewFileUploadIcon(fileUploadID string) *Icon {
+	return &Icon{Type: "file_upload", FileUpload: &FileUploadRef{ID: fileUploadID}}
+}
+
+// NewExternalCover returns a page cover showing the image at the URL.
+func NewExternalCover(url string) *File {
+	return &File{Type: "external", External: &External{URL: url}}
+}
+
+// NewFileUploadCover returns a page cover showing a file uploaded with the File Upload API.
+func NewFileUploadCover(fileUploadID string) *File {
+	return &File{Type: "file_upload", FileUpload: &FileUploadRef{ID: fileUploadID}}
+}
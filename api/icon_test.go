@@ -0,0 +1,32 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIconBuilders_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"emoji icon", NewEmojiIcon("📚"), `{"type":"emoji","emoji":"📚"}`},
+		{"external icon", NewExternalIcon("https://example.com/i.png"), `{"type":"external","external":{"url":"https://example.com/i.png"}}`},
+		{"uploaded icon", NewFileUploadIcon("up-1"), `{"type":"file_upload","file_upload":{"id":"up-1"}}`},
+		{"external cover", NewExternalCover("https://example.com/c.png"), `{"type":"external","external":{"url":"https://example.com/c.png"}}`},
+		{"uploaded cover", NewFileUploadCover("up-2"), `{"type":"file_upload","file_upload":{"id":"up-2"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
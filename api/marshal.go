@@ -0,0 +1,757 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PropertyMarshaler is implemented by types that convert themselves into a property value. The
+// Type of the returned value defaults to the type in the struct tag.
+type PropertyMarshaler interface {
+	MarshalProperty() (ValueProperty, error)
+}
+
+// PropertyUnmarshaler is implemented by types that read themselves from a property value.
+type PropertyUnmarshaler interface {
+	UnmarshalProperty(ValueProperty) error
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*PropertyMarshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*PropertyUnmarshaler)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	dateRangeType   = reflect.TypeOf(DateRange{})
+	richTextsType   = reflect.TypeOf([]RichText{})
+	valuePropType   = reflect.TypeOf(ValueProperty{})
+	commonType      = reflect.TypeOf(CommonObject{})
+)
+
+// PropertyError is the error of a single property in MarshalPage and UnmarshalPage.
+type PropertyError struct {
+	// Name of the property.
+	Property string
+
+	// Name of the struct field.
+	Field string
+
+	Err error
+}
+
+func (e *PropertyError) Error() string {
+	return fmt.Sprintf("property %q (field %s): %v", e.Property, e.Field, e.Err)
+}
+
+func (e *PropertyError) Unwrap() error {
+	return e.Err
+}
+
+// PropertyErrors is returned by MarshalPage and UnmarshalPage when some properties could not be
+// converted. The other properties are converted nonetheless.
+type PropertyErrors []*PropertyError
+
+func (e PropertyErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, pe := range e {
+		msgs[i] = pe.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// field is a struct field mapped to a property with a `notion:"Name,type,omitempty"` tag.
+type field struct {
+	index     []int
+	name      string
+	prop      string
+	typ       ValuePropertyType
+	inferred  bool
+	omitEmpty bool
+}
+
+// MarshalPage converts a struct into a page, using the `notion` tags of its fields:
+//
+//	type Book struct {
+//		api.CommonObject
+//		Name     string     `notion:"Name,title"`
+//		Category string     `notion:"Category,select"`
+//		Tags     []string   `notion:"Tags,multi_select"`
+//		Pages    *int       `notion:"Pages"`
+//		Read     time.Time  `notion:"Read,date,omitempty"`
+//		Authors  []string   `notion:"Authors,relation"`
+//	}
+//
+// The tag holds the name of the property, its type and options. The type can be left out for
+// strings (rich_text), numbers (number), bools (checkbox), time.Time and DateRange (date) and
+// []string (multi_select). With omitempty, zero values are left out of the page; otherwise they
+// are written, so that they clear the property on update. Nil pointers always clear the property.
+// Fields without a tag, or with the tag "-", are ignored.
+//
+// Strings map to title, rich_text, select, status, url, email and phone_number; []string to
+// multi_select, relation and people IDs, and files URLs; time.Time to date, a date when it is
+// midnight in its location and a date time otherwise. Read-only properties, e.g. formula or
+// created_time, are not written. Fields implementing PropertyMarshaler convert themselves, and
+// ValueProperty fields hold the raw value, e.g. for formulas whose result type is not known.
+//
+// An embedded CommonObject is copied into the page. The fields of a struct embedded through a nil
+// pointer are left out of the page.
+func MarshalPage(v interface{}) (Page, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return Page{}, fmt.Errorf("cannot marshal %T into a page, it is not a struct", v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return Page{}, err
+	}
+
+	pg := Page{Properties: map[string]ValueProperty{}}
+	if f, ok := rv.Type().FieldByName("CommonObject"); ok && f.Anonymous && f.Type == commonType {
+		if fv, err := rv.FieldByIndexErr(f.Index); err == nil {
+			pg.CommonObject = fv.Interface().(CommonObject)
+		}
+	}
+
+	var errs PropertyErrors
+	for _, f := range fields {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil || (f.omitEmpty && fv.IsZero()) {
+			continue
+		}
+
+		vp, ok, err := encodeValue(fv, f.typ)
+		if err != nil {
+			errs = append(errs, &PropertyError{Property: f.prop, Field: f.name, Err: err})
+			continue
+		}
+
+		if ok {
+			pg.Properties[f.prop] = vp
+		}
+	}
+
+	if len(errs) > 0 {
+		return pg, errs
+	}
+
+	return pg, nil
+}
+
+// UnmarshalPage fills the struct pointed to by v from the properties of the page, see
+// MarshalPage for the mapping. Properties that are missing from the page leave their field
+// untouched; empty values set pointers to nil and other fields to their zero value. An embedded
+// CommonObject is filled from the page, and structs embedded through a nil pointer are allocated
+// when one of their fields is set.
+//
+// Properties that cannot be converted are returned as PropertyErrors, after all the other
+// properties have been set.
+func UnmarshalPage(pg Page, v interface{}) error {
+	if err := CheckUnmarshalTarget(v); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	if f, ok := rv.Type().FieldByName("CommonObject"); ok && f.Anonymous && f.Type == commonType {
+		if fv, err := settableField(rv, f.Index); err == nil {
+			fv.Set(reflect.ValueOf(pg.CommonObject))
+		}
+	}
+
+	var errs PropertyErrors
+	for _, f := range fields {
+		vp, ok := pg.Properties[f.prop]
+		if !ok {
+			continue
+		}
+
+		// Inferred types only matter when writing, e.g. a string reads from a title as well.
+		if !f.inferred && vp.Type != f.typ {
+			errs = append(errs, &PropertyError{Property: f.prop, Field: f.name,
+				Err: fmt.Errorf("property is of type %s, not %s", vp.Type, f.typ)})
+			continue
+		}
+
+		fv, err := settableField(rv, f.index)
+		if err == nil {
+			err = decodeValue(vp, fv)
+		}
+		if err != nil {
+			errs = append(errs, &PropertyError{Property: f.prop, Field: f.name, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// CheckUnmarshalTarget returns the error UnmarshalPage returns for v whatever the page: v must be
+// a pointer to a struct, and its notion tags must be valid.
+func CheckUnmarshalTarget(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot unmarshal a page into %T, it is not a pointer to a struct", v)
+	}
+
+	_, err := structFields(rv.Elem().Type())
+	return err
+}
+
+// settableField returns the field of v with the given index, allocating the structs embedded
+// through nil pointers on the way.
+func settableField(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot allocate the unexported embedded %s", v.Type())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+func structFields(t reflect.Type) ([]field, error) {
+	var ret []field
+
+	for _, sf := range reflect.VisibleFields(t) {
+		tag, ok := sf.Tag.Lookup("notion")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		f := field{index: sf.Index, name: sf.Name, prop: parts[0]}
+		if f.prop == "" {
+			f.prop = sf.Name
+		}
+
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "":
+			default:
+				f.typ = ValuePropertyType(opt)
+			}
+		}
+
+		if f.typ == "" {
+			f.typ, f.inferred = inferType(sf.Type), true
+		}
+
+		if f.typ == "" && !implements(sf.Type) && sf.Type != valuePropType {
+			return nil, fmt.Errorf("field %s of type %s needs a property type in its notion tag", sf.Name, sf.Type)
+		}
+
+		ret = append(ret, f)
+	}
+
+	return ret, nil
+}
+
+// implements reports whether a field of type t converts itself, with methods on t or on *t.
+func implements(t reflect.Type) bool {
+	for _, typ := range []reflect.Type{t, reflect.PointerTo(t)} {
+		if typ.Implements(marshalerType) || typ.Implements(unmarshalerType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func inferType(t reflect.Type) ValuePropertyType {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType, t == dateRangeType:
+		return ValuePropertyTypeDate
+	case t == richTextsType:
+		return ValuePropertyTypeRichText
+	case t.Kind() == reflect.String:
+		return ValuePropertyTypeRichText
+	case t.Kind() == reflect.Bool:
+		return ValuePropertyTypeCheckbox
+	case isNumber(t.Kind()):
+		return ValuePropertyTypeNumber
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		return ValuePropertyTypeMultiSelect
+	}
+
+	return ""
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// encodeValue converts the field into a property value. ok is false for read-only properties.
+func encodeValue(fv reflect.Value, typ ValuePropertyType) (vp ValueProperty, ok bool, err error) {
+	if fv.Kind() != reflect.Pointer && fv.CanAddr() && fv.Addr().Type().Implements(marshalerType) {
+		fv = fv.Addr()
+	}
+	if fv.Type().Implements(marshalerType) && !(fv.Kind() == reflect.Pointer && fv.IsNil()) {
+		vp, err := fv.Interface().(PropertyMarshaler).MarshalProperty()
+		if vp.Type == "" {
+			vp.Type = typ
+		}
+		return vp, err == nil, err
+	}
+
+	if fv.Type() == valuePropType {
+		vp = fv.Interface().(ValueProperty)
+		if typ != "" {
+			vp.Type = typ
+		}
+		_, writable := vp.writableValue()
+		return vp, writable, nil
+	}
+
+	vp = ValueProperty{Type: typ}
+	if _, writable := vp.writableValue(); !writable {
+		return vp, false, nil
+	}
+
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return vp, true, nil
+		}
+		fv = fv.Elem()
+	}
+
+	mismatch := func() (ValueProperty, bool, error) {
+		return vp, false, fmt.Errorf("cannot write a %s into a %s property", fv.Type(), typ)
+	}
+
+	switch typ {
+	case ValuePropertyTypeTitle, ValuePropertyTypeRichText:
+		var rts []RichText
+		switch {
+		case fv.Type() == richTextsType:
+			rts = fv.Interface().([]RichText)
+		case fv.Kind() == reflect.String:
+			if s := fv.String(); s != "" {
+				rts = []RichText{NewText(s)}
+			}
+		default:
+			return mismatch()
+		}
+
+		if typ == ValuePropertyTypeTitle {
+			vp.Title = rts
+		} else {
+			vp.RichText = rts
+		}
+	case ValuePropertyTypeNumber:
+		n, ok := toFloat(fv)
+		if !ok {
+			return mismatch()
+		}
+		vp.Number = &n
+	case ValuePropertyTypeCheckbox:
+		if fv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		vp.Checkbox = fv.Bool()
+	case ValuePropertyTypeSelect, ValuePropertyTypeStatus:
+		if fv.Kind() != reflect.String {
+			return mismatch()
+		}
+		if s := fv.String(); s != "" {
+			if typ == ValuePropertyTypeSelect {
+				vp.Select = &Option{Name: s}
+			} else {
+				vp.Status = &Option{Name: s}
+			}
+		}
+	case ValuePropertyTypeURL, ValuePropertyTypeEmail, ValuePropertyTypePhoneNumber:
+		if fv.Kind() != reflect.String {
+			return mismatch()
+		}
+		switch typ {
+		case ValuePropertyTypeURL:
+			vp.URL = fv.String()
+		case ValuePropertyTypeEmail:
+			vp.Email = fv.String()
+		default:
+			vp.PhoneNumber = fv.String()
+		}
+	case ValuePropertyTypeDate:
+		switch {
+		case fv.Type() == timeType:
+			if t := fv.Interface().(time.Time); !t.IsZero() {
+				d := dateOrDateTime(t)
+				vp.Date = &d
+			}
+		case fv.Type() == dateRangeType:
+			d := fv.Interface().(DateRange)
+			vp.Date = &d
+		case fv.Kind() == reflect.String:
+			if s := fv.String(); s != "" {
+				vp.Date = &DateRange{Start: s}
+			}
+		default:
+			return mismatch()
+		}
+	case ValuePropertyTypeMultiSelect, ValuePropertyTypeRelation, ValuePropertyTypePeople, ValuePropertyTypeFiles:
+		ss, ok := toStrings(fv)
+		if !ok {
+			return mismatch()
+		}
+		for _, s := range ss {
+			switch typ {
+			case ValuePropertyTypeMultiSelect:
+				vp.MultiSelect = append(vp.MultiSelect, Option{Name: s})
+			case ValuePropertyTypeRelation:
+				vp.Relation = append(vp.Relation, Relation{ID: s})
+			case ValuePropertyTypePeople:
+				vp.People = append(vp.People, User{Object: "user", ID: s})
+			default:
+				vp.Files = append(vp.Files, File{Name: fileName(s), Type: "external", External: &External{URL: s}})
+			}
+		}
+	}
+
+	return vp, true, nil
+}
+
+// dateOrDateTime returns a date when t is midnight in its location, and a date time otherwise.
+func dateOrDateTime(t time.Time) DateRange {
+	if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())) {
+		return NewDate(t)
+	}
+
+	return NewDateTime(t)
+}
+
+func fileName(u string) string {
+	if pu, err := url.Parse(u); err == nil && path.Base(pu.Path) != "/" && path.Base(pu.Path) != "." {
+		return path.Base(pu.Path)
+	}
+
+	return u
+}
+
+func toFloat(fv reflect.Value) (float64, bool) {
+	switch {
+	case fv.CanInt():
+		return float64(fv.Int()), true
+	case fv.CanUint():
+		return float64(fv.Uint()), true
+	case fv.CanFloat():
+		return fv.Float(), true
+	}
+
+	return 0, false
+}
+
+// toStrings converts a string or a slice of strings into a slice of strings.
+func toStrings(fv reflect.Value) ([]string, bool) {
+	switch {
+	case fv.Kind() == reflect.String:
+		if fv.String() == "" {
+			return nil, true
+		}
+		return []string{fv.String()}, true
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		ret := make([]string, fv.Len())
+		for i := range ret {
+			ret[i] = fv.Index(i).String()
+		}
+		return ret, true
+	}
+
+	return nil, false
+}
+
+// isEmptyValue reports whether the property has no value, which sets pointers to nil.
+func isEmptyValue(vp ValueProperty) bool {
+	switch vp.Type {
+	case ValuePropertyTypeTitle:
+		return len(vp.Title) == 0
+	case ValuePropertyTypeRichText:
+		return len(vp.RichText) == 0
+	case ValuePropertyTypeNumber:
+		return vp.Number == nil
+	case ValuePropertyTypeSelect:
+		return vp.Select == nil
+	case ValuePropertyTypeStatus:
+		return vp.Status == nil
+	case ValuePropertyTypeMultiSelect:
+		return len(vp.MultiSelect) == 0
+	case ValuePropertyTypeDate:
+		return vp.Date == nil || vp.Date.Start == ""
+	case ValuePropertyTypeURL:
+		return vp.URL == ""
+	case ValuePropertyTypeEmail:
+		return vp.Email == ""
+	case ValuePropertyTypePhoneNumber:
+		return vp.PhoneNumber == ""
+	case ValuePropertyTypeRelation:
+		return len(vp.Relation) == 0
+	case ValuePropertyTypePeople:
+		return len(vp.People) == 0
+	case ValuePropertyTypeFiles:
+		return len(vp.Files) == 0
+	case ValuePropertyTypeCreatedBy:
+		return vp.CreatedBy == nil
+	case ValuePropertyTypeLastEditedBy:
+		return vp.LastEditedBy == nil
+	case ValuePropertyTypeUniqueID:
+		return vp.UniqueID == nil
+	case ValuePropertyTypeFormula:
+		return vp.Formula == nil || (vp.Formula.String == nil && vp.Formula.Number == nil &&
+			vp.Formula.Boolean == nil && vp.Formula.Date == nil)
+	case ValuePropertyTypeRollup:
+		return vp.Rollup == nil || (vp.Rollup.Number == nil && vp.Rollup.Date == nil && len(vp.Rollup.Array) == 0)
+	case ValuePropertyTypeVerification:
+		return vp.Verification == nil
+	}
+
+	return false
+}
+
+func decodeValue(vp ValueProperty, fv reflect.Value) error {
+	if fv.Kind() == reflect.Pointer && fv.Type().Implements(unmarshalerType) {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return fv.Interface().(PropertyUnmarshaler).UnmarshalProperty(vp)
+	}
+
+	if fv.CanAddr() && fv.Addr().Type().Implements(unmarshalerType) {
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			return fv.Interface().(PropertyUnmarshaler).UnmarshalProperty(vp)
+		}
+		return fv.Addr().Interface().(PropertyUnmarshaler).UnmarshalProperty(vp)
+	}
+
+	if fv.Type() == valuePropType {
+		fv.Set(reflect.ValueOf(vp))
+		return nil
+	}
+
+	if fv.Kind() == reflect.Pointer {
+		if isEmptyValue(vp) {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+
+		return decodeValue(vp, fv.Elem())
+	}
+
+	if isEmptyValue(vp) {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("cannot read a %s property into a %s", vp.Type, fv.Type())
+	}
+
+	switch vp.Type {
+	case ValuePropertyTypeTitle, ValuePropertyTypeRichText:
+		rts := vp.Title
+		if vp.Type == ValuePropertyTypeRichText {
+			rts = vp.RichText
+		}
+		if fv.Type() == richTextsType {
+			fv.Set(reflect.ValueOf(rts))
+			return nil
+		}
+		return setString(fv, PlainText(rts), mismatch)
+	case ValuePropertyTypeNumber:
+		return setNumber(fv, *vp.Number, mismatch)
+	case ValuePropertyTypeCheckbox:
+		if fv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		fv.SetBool(vp.Checkbox)
+	case ValuePropertyTypeSelect:
+		return setString(fv, vp.Select.Name, mismatch)
+	case ValuePropertyTypeStatus:
+		return setString(fv, vp.Status.Name, mismatch)
+	case ValuePropertyTypeURL:
+		return setString(fv, vp.URL, mismatch)
+	case ValuePropertyTypeEmail:
+		return setString(fv, vp.Email, mismatch)
+	case ValuePropertyTypePhoneNumber:
+		return setString(fv, vp.PhoneNumber, mismatch)
+	case ValuePropertyTypeDate:
+		return setDate(fv, *vp.Date, mismatch)
+	case ValuePropertyTypeCreatedTime:
+		return setDate(fv, DateRange{Start: vp.CreatedTime}, mismatch)
+	case ValuePropertyTypeLastEditedTime:
+		return setDate(fv, DateRange{Start: vp.LastEditedTime}, mismatch)
+	case ValuePropertyTypeMultiSelect, ValuePropertyTypeRelation, ValuePropertyTypePeople, ValuePropertyTypeFiles:
+		var ss []string
+		for _, o := range vp.MultiSelect {
+			ss = append(ss, o.Name)
+		}
+		for _, r := range vp.Relation {
+			ss = append(ss, r.ID)
+		}
+		for _, u := range vp.People {
+			ss = append(ss, u.ID)
+		}
+		for _, f := range vp.Files {
+			switch {
+			case f.External != nil:
+				ss = append(ss, f.External.URL)
+			case f.File != nil:
+				ss = append(ss, f.File.URL)
+			}
+		}
+		return setStrings(fv, ss, mismatch)
+	case ValuePropertyTypeCreatedBy:
+		return setString(fv, vp.CreatedBy.ID, mismatch)
+	case ValuePropertyTypeLastEditedBy:
+		return setString(fv, vp.LastEditedBy.ID, mismatch)
+	case ValuePropertyTypeUniqueID:
+		if fv.Kind() == reflect.String {
+			id := strconv.Itoa(vp.UniqueID.Number)
+			if vp.UniqueID.Prefix != nil && *vp.UniqueID.Prefix != "" {
+				id = *vp.UniqueID.Prefix + "-" + id
+			}
+			fv.SetString(id)
+			return nil
+		}
+		return setNumber(fv, float64(vp.UniqueID.Number), mismatch)
+	case ValuePropertyTypeFormula:
+		f := vp.Formula
+		switch {
+		case f.String != nil:
+			return setString(fv, *f.String, mismatch)
+		case f.Number != nil:
+			return setNumber(fv, *f.Number, mismatch)
+		case f.Boolean != nil:
+			if fv.Kind() != reflect.Bool {
+				return mismatch()
+			}
+			fv.SetBool(*f.Boolean)
+		default:
+			return setDate(fv, *f.Date, mismatch)
+		}
+	case ValuePropertyTypeRollup:
+		switch {
+		case vp.Rollup.Number != nil:
+			return setNumber(fv, *vp.Rollup.Number, mismatch)
+		case vp.Rollup.Date != nil:
+			return setDate(fv, *vp.Rollup.Date, mismatch)
+		}
+		return errors.New("array rollups can only be read by a PropertyUnmarshaler")
+	case ValuePropertyTypeVerification:
+		return setString(fv, vp.Verification.State, mismatch)
+	default:
+		return fmt.Errorf("%s properties can only be read by a PropertyUnmarshaler", vp.Type)
+	}
+
+	return nil
+}
+
+func setString(fv reflect.Value, s string, mismatch func() error) error {
+	if fv.Kind() != reflect.String {
+		return mismatch()
+	}
+
+	fv.SetString(s)
+	return nil
+}
+
+func setStrings(fv reflect.Value, ss []string, mismatch func() error) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		switch len(ss) {
+		case 0:
+			fv.SetString("")
+		case 1:
+			fv.SetString(ss[0])
+		default:
+			return fmt.Errorf("cannot read %d values into a string", len(ss))
+		}
+	case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+		sv := reflect.MakeSlice(fv.Type(), len(ss), len(ss))
+		for i, s := range ss {
+			sv.Index(i).SetString(s)
+		}
+		fv.Set(sv)
+	default:
+		return mismatch()
+	}
+
+	return nil
+}
+
+func setNumber(fv reflect.Value, n float64, mismatch func() error) error {
+	switch {
+	case fv.CanInt():
+		if n != float64(int64(n)) || fv.OverflowInt(int64(n)) {
+			return fmt.Errorf("%v does not fit in a %s", n, fv.Type())
+		}
+		fv.SetInt(int64(n))
+	case fv.CanUint():
+		if n < 0 || n != float64(uint64(n)) || fv.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%v does not fit in a %s", n, fv.Type())
+		}
+		fv.SetUint(uint64(n))
+	case fv.CanFloat():
+		fv.SetFloat(n)
+	default:
+		return mismatch()
+	}
+
+	return nil
+}
+
+func setDate(fv reflect.Value, d DateRange, mismatch func() error) error {
+	switch {
+	case fv.Type() == timeType:
+		t, _, err := d.StartTime()
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(t))
+	case fv.Type() == dateRangeType:
+		fv.Set(reflect.ValueOf(d))
+	case fv.Kind() == reflect.String:
+		fv.SetString(d.Start)
+	default:
+		return mismatch()
+	}
+
+	return nil
+}
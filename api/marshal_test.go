@@ -0,0 +1,390 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type priority int
+
+func (p priority) MarshalProperty() (ValueProperty, error) {
+	return ValueProperty{Select: &Option{Name: fmt.Sprintf("P%d", p)}}, nil
+}
+
+func (p *priority) UnmarshalProperty(vp ValueProperty) error {
+	if vp.Select == nil {
+		*p = 0
+		return nil
+	}
+
+	_, err := fmt.Sscanf(vp.Select.Name, "P%d", (*int)(p))
+	return err
+}
+
+type book struct {
+	CommonObject
+	Name     string    `notion:"Name,title"`
+	Notes    string    `notion:"Notes"`
+	Pages    *int      `notion:"Pages"`
+	Rating   float64   `notion:"Rating,omitempty"`
+	Read     bool      `notion:"Read"`
+	Category string    `notion:"Category,select"`
+	Tags     []string  `notion:"Tags"`
+	Started  time.Time `notion:"Started,omitempty"`
+	Finished time.Time `notion:"Finished,date,omitempty"`
+	Authors  []string  `notion:"Authors,relation"`
+	Owner    string    `notion:"Owner,people"`
+	Link     string    `notion:"Link,url"`
+	Priority priority  `notion:"Priority,select"`
+	ID       string    `notion:"ID,unique_id"`
+	Total    *float64  `notion:"Total,formula"`
+	Ignored  string    `notion:"-"`
+	Untagged string
+}
+
+func TestMarshalPage(t *testing.T) {
+	b := book{
+		CommonObject: CommonObject{ID: "page-1"},
+		Name:         "Dune",
+		Rating:       4.5,
+		Category:     "Fiction",
+		Tags:         []string{"sci-fi", "classic"},
+		Started:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Finished:     time.Date(2026, 10, 18, 21, 30, 0, 0, time.UTC),
+		Authors:      []string{"author-1"},
+		Owner:        "user-1",
+		Priority:     1,
+		ID:           "BOOK-1",
+		Ignored:      "x",
+		Untagged:     "y",
+	}
+
+	pg, err := MarshalPage(&b)
+	if err != nil {
+		t.Fatalf("MarshalPage failed: %v", err)
+	}
+	if pg.ID != "page-1" {
+		t.Errorf("expected ID page-1, got %q", pg.ID)
+	}
+
+	got, err := json.Marshal(pg.Properties)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	want := `{` +
+		`"Authors":{"relation":[{"id":"author-1"}],"type":"relation"},` +
+		`"Category":{"select":{"name":"Fiction"},"type":"select"},` +
+		`"Finished":{"date":{"start":"2026-10-18T21:30:00.000Z"},"type":"date"},` +
+		`"Link":{"type":"url","url":null},` +
+		`"Name":{"title":[{"type":"text","text":{"content":"Dune"}}],"type":"title"},` +
+		`"Notes":{"rich_text":[],"type":"rich_text"},` +
+		`"Owner":{"people":[{"object":"user","id":"user-1"}],"type":"people"},` +
+		`"Pages":{"number":null,"type":"number"},` +
+		`"Priority":{"select":{"name":"P1"},"type":"select"},` +
+		`"Rating":{"number":4.5,"type":"number"},` +
+		`"Read":{"checkbox":false,"type":"checkbox"},` +
+		`"Started":{"date":{"start":"2026-10-01"},"type":"date"},` +
+		`"Tags":{"multi_select":[{"name":"sci-fi"},{"name":"classic"}],"type":"multi_select"}` +
+		`}`
+	if string(got) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestMarshalPage_Errors(t *testing.T) {
+	if _, err := MarshalPage("not a struct"); err == nil {
+		t.Error("expected error for non-struct")
+	}
+
+	var noType struct {
+		Data map[string]string `notion:"Data"`
+	}
+	if _, err := MarshalPage(noType); err == nil {
+		t.Error("expected error for field without a property type")
+	}
+
+	var mismatch struct {
+		Score bool `notion:"Score,number"`
+		Name  int  `notion:"Name,title"`
+	}
+	_, err := MarshalPage(mismatch)
+
+	var errs PropertyErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("expected two property errors, got %v", err)
+	}
+	if errs[0].Property != "Score" || errs[1].Field != "Name" {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestUnmarshalPage(t *testing.T) {
+	pg := Page{
+		CommonObject: CommonObject{ID: "page-1"},
+		Properties: map[string]ValueProperty{
+			"Name":     {Type: ValuePropertyTypeTitle, Title: []RichText{NewText("Du"), NewText("ne")}},
+			"Notes":    {Type: ValuePropertyTypeRichText},
+			"Pages":    {Type: ValuePropertyTypeNumber, Number: Ptr(412.0)},
+			"Rating":   {Type: ValuePropertyTypeNumber},
+			"Read":     {Type: ValuePropertyTypeCheckbox, Checkbox: true},
+			"Category": {Type: ValuePropertyTypeSelect, Select: &Option{Name: "Fiction"}},
+			"Tags":     {Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "sci-fi"}}},
+			"Started":  {Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2026-10-01"}},
+			"Finished": {Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2026-10-18T21:30:00.000+02:00"}},
+			"Authors":  {Type: ValuePropertyTypeRelation, Relation: []Relation{{ID: "a1"}, {ID: "a2"}}},
+			"Owner":    {Type: ValuePropertyTypePeople, People: []User{{ID: "user-1"}}},
+			"Link":     {Type: ValuePropertyTypeURL, URL: "https://example.com"},
+			"Priority": {Type: ValuePropertyTypeSelect, Select: &Option{Name: "P2"}},
+			"ID":       {Type: ValuePropertyTypeUniqueID, UniqueID: &UniqueIDValue{Prefix: Ptr("BOOK"), Number: 7}},
+			"Total":    {Type: ValuePropertyTypeFormula, Formula: &FormulaValue{Type: "number", Number: Ptr(3.0)}},
+		},
+	}
+
+	b := book{Rating: 1, Ignored: "kept"}
+	if err := UnmarshalPage(pg, &b); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+
+	want := book{
+		CommonObject: CommonObject{ID: "page-1"},
+		Name:         "Dune",
+		Pages:        Ptr(412),
+		Read:         true,
+		Category:     "Fiction",
+		Tags:         []string{"sci-fi"},
+		Started:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Authors:      []string{"a1", "a2"},
+		Owner:        "user-1",
+		Link:         "https://example.com",
+		Priority:     2,
+		ID:           "BOOK-7",
+		Total:        Ptr(3.0),
+		Ignored:      "kept",
+	}
+
+	if !b.Finished.Equal(time.Date(2026, 10, 18, 19, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected finished time %v", b.Finished)
+	}
+	b.Finished = time.Time{}
+
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("expected\n%+v\ngot\n%+v", want, b)
+	}
+}
+
+func TestUnmarshalPage_NullAndMissing(t *testing.T) {
+	var b struct {
+		Pages   *int     `notion:"Pages"`
+		Tags    []string `notion:"Tags"`
+		Missing string   `notion:"Missing"`
+	}
+	b.Pages = Ptr(1)
+	b.Tags = []string{"x"}
+	b.Missing = "kept"
+
+	pg := Page{Properties: map[string]ValueProperty{
+		"Pages": {Type: ValuePropertyTypeNumber},
+		"Tags":  {Type: ValuePropertyTypeMultiSelect},
+	}}
+	if err := UnmarshalPage(pg, &b); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if b.Pages != nil || b.Tags != nil || b.Missing != "kept" {
+		t.Errorf("unexpected result %+v", b)
+	}
+}
+
+func TestUnmarshalPage_Errors(t *testing.T) {
+	var b book
+	if err := UnmarshalPage(Page{}, b); err == nil {
+		t.Error("expected error for non-pointer")
+	}
+
+	var dst struct {
+		Name  string  `notion:"Name,title"`
+		Pages int8    `notion:"Pages"`
+		Read  string  `notion:"Read"`
+		Score float64 `notion:"Score"`
+	}
+	pg := Page{Properties: map[string]ValueProperty{
+		"Name":  {Type: ValuePropertyTypeRichText, RichText: []RichText{NewText("x")}},
+		"Pages": {Type: ValuePropertyTypeNumber, Number: Ptr(1000.0)},
+		"Read":  {Type: ValuePropertyTypeCheckbox, Checkbox: true},
+		"Score": {Type: ValuePropertyTypeNumber, Number: Ptr(1.5)},
+	}}
+
+	err := UnmarshalPage(pg, &dst)
+
+	var errs PropertyErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("expected three property errors, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `property "Pages" (field Pages): 1000 does not fit in a int8`) {
+		t.Errorf("unexpected error %v", err)
+	}
+	if dst.Score != 1.5 {
+		t.Errorf("expected the other properties to be set, got %+v", dst)
+	}
+}
+
+func TestMarshalPage_RoundTrip(t *testing.T) {
+	in := book{
+		Name:     "Dune",
+		Pages:    Ptr(412),
+		Tags:     []string{"a", "b"},
+		Started:  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Authors:  []string{"a1"},
+		Priority: 3,
+	}
+
+	pg, err := MarshalPage(in)
+	if err != nil {
+		t.Fatalf("MarshalPage failed: %v", err)
+	}
+
+	var out book
+	if err := UnmarshalPage(pg, &out); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("expected\n%+v\ngot\n%+v", in, out)
+	}
+}
+
+func TestMarshalPage_RawValue(t *testing.T) {
+	var b struct {
+		Total  ValueProperty `notion:"Total,formula"`
+		Status ValueProperty `notion:"Status"`
+	}
+
+	pg := Page{Properties: map[string]ValueProperty{
+		"Total":  {Type: ValuePropertyTypeFormula, Formula: &FormulaValue{Type: "string", String: Ptr("x")}},
+		"Status": {Type: ValuePropertyTypeStatus, Status: &Option{Name: "Done"}},
+	}}
+	if err := UnmarshalPage(pg, &b); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if *b.Total.Formula.String != "x" || b.Status.Status.Name != "Done" {
+		t.Errorf("unexpected result %+v", b)
+	}
+
+	out, err := MarshalPage(b)
+	if err != nil {
+		t.Fatalf("MarshalPage failed: %v", err)
+	}
+	if _, ok := out.Properties["Total"]; ok {
+		t.Error("expected the read-only formula to be left out")
+	}
+	if out.Properties["Status"].Status.Name != "Done" {
+		t.Errorf("unexpected properties %+v", out.Properties)
+	}
+}
+
+// isbn only has pointer receivers, so fields of type *isbn convert themselves.
+type isbn string
+
+func (i *isbn) MarshalProperty() (ValueProperty, error) {
+	return ValueProperty{Type: ValuePropertyTypeRichText, RichText: NewTexts("ISBN " + string(*i))}, nil
+}
+
+func (i *isbn) UnmarshalProperty(vp ValueProperty) error {
+	*i = isbn(strings.TrimPrefix(PlainText(vp.RichText), "ISBN "))
+	return nil
+}
+
+func TestMarshalPage_PointerMethods(t *testing.T) {
+	type edition struct {
+		ISBN  *isbn `notion:"ISBN"`
+		Other isbn  `notion:"Other"`
+	}
+
+	id := isbn("978-0441013593")
+	pg, err := MarshalPage(edition{ISBN: &id, Other: "0441172717"})
+	if err != nil {
+		t.Fatalf("MarshalPage failed: %v", err)
+	}
+	if got := PlainText(pg.Properties["ISBN"].RichText); got != "ISBN 978-0441013593" {
+		t.Errorf("expected the ISBN to convert itself, got %q", got)
+	}
+
+	var got edition
+	if err := UnmarshalPage(pg, &got); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if got.ISBN == nil || *got.ISBN != id || got.Other != "0441172717" {
+		t.Errorf("unexpected edition %+v", got)
+	}
+}
+
+func TestCheckUnmarshalTarget(t *testing.T) {
+	if err := CheckUnmarshalTarget(&book{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckUnmarshalTarget(book{}); err == nil {
+		t.Error("expected an error for a struct that is not a pointer")
+	}
+	if err := CheckUnmarshalTarget(&struct {
+		Data []byte `notion:"Data"`
+	}{}); err == nil {
+		t.Error("expected an error for a field without a property type")
+	}
+}
+
+// Imprint is exported so that a nil pointer to it can be allocated when it is embedded.
+type Imprint struct {
+	Publisher string `notion:"Publisher,select"`
+	Year      int    `notion:"Year,omitempty"`
+}
+
+type bookEdition struct {
+	*Imprint
+	Name string `notion:"Name,title"`
+}
+
+func TestMarshalPage_NilEmbeddedPointer(t *testing.T) {
+	pg, err := MarshalPage(bookEdition{Name: "Dune"})
+	if err != nil {
+		t.Fatalf("MarshalPage failed: %v", err)
+	}
+	if _, ok := pg.Properties["Publisher"]; ok || len(pg.Properties) != 1 {
+		t.Errorf("expected only the Name property, got %+v", pg.Properties)
+	}
+}
+
+func TestUnmarshalPage_NilEmbeddedPointer(t *testing.T) {
+	pg := Page{Properties: map[string]ValueProperty{
+		"Name":      {Type: ValuePropertyTypeTitle, Title: NewTexts("Dune")},
+		"Publisher": {Type: ValuePropertyTypeSelect, Select: &Option{Name: "Chilton"}},
+	}}
+
+	var got bookEdition
+	if err := UnmarshalPage(pg, &got); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if got.Imprint == nil || got.Publisher != "Chilton" || got.Name != "Dune" {
+		t.Errorf("unexpected book %+v", got)
+	}
+}
+
+func TestUnmarshalPage_FilesWithoutURL(t *testing.T) {
+	var got struct {
+		Cover string `notion:"Cover,files"`
+	}
+	got.Cover = "old"
+
+	pg := Page{Properties: map[string]ValueProperty{
+		"Cover": {Type: ValuePropertyTypeFiles, Files: []File{{Name: "cover.png", FileUpload: &FileUploadRef{ID: "up-1"}}}},
+	}}
+	if err := UnmarshalPage(pg, &got); err != nil {
+		t.Fatalf("UnmarshalPage failed: %v", err)
+	}
+	if got.Cover != "" {
+		t.Errorf("expected no URL, got %q", got.Cover)
+	}
+}
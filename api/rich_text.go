@@ -0,0 +1,209 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PlainText returns the text of the rich text runs without any formatting.
+func PlainText(rts []RichText) string {
+	var sb strings.Builder
+	for _, rt := range rts {
+		sb.WriteString(rt.String())
+	}
+
+	return sb.String()
+}
+
+// String returns the text of the run without any formatting. It is the plain_text returned by
+// Notion, or, for runs built locally, the text content or the equation expression.
+func (rt RichText) String() string {
+	if rt.PlainText != "" {
+		return rt.PlainText
+	}
+
+	switch {
+	case rt.Text != nil:
+		return rt.Text.Content
+	case rt.Equation != nil:
+		return rt.Equation.Expression
+	}
+
+	return ""
+}
+
+// WithAnnotations returns a copy of the run with the given annotations.
+func (rt RichText) WithAnnotations(a Annotation) RichText {
+	rt.Annotations = &a
+	return rt
+}
+
+// NewText returns a run of plain text.
+func NewText(content string) RichText {
+	return RichText{Type: RichTextTypeText, Text: &Text{Content: content}}
+}
+
+// NewLink returns a run of text that links to the given URL.
+func NewLink(content, url string) RichText {
+	return RichText{Type: RichTextTypeText, Text: &Text{Content: content, Link: &Link{URL: url}}}
+}
+
+// NewEquation returns an inline KaTeX equation.
+func NewEquation(expression string) RichText {
+	return RichText{Type: RichTextTypeEquation, Equation: &Equation{Expression: expression}}
+}
+
+// NewUserMention returns a mention of the user with the given id.
+func NewUserMention(userID string) RichText {
+	return newMention(Mention{Type: MentionTypeUser, User: &User{Object: "user", ID: userID}})
+}
+
+// NewPageMention returns a mention of the page with the given id.
+func NewPageMention(pageID string) RichText {
+	return newMention(Mention{Type: MentionTypePage, Page: &MentionRef{ID: pageID}})
+}
+
+// NewDatabaseMention returns a mention of the database with the given id.
+func NewDatabaseMention(databaseID string) RichText {
+	return newMention(Mention{Type: MentionTypeDatabase, Database: &MentionRef{ID: databaseID}})
+}
+
+// NewDateMention returns a mention of a date or a date range.
+func NewDateMention(date DateRange) RichText {
+	return newMention(Mention{Type: MentionTypeDate, Date: &date})
+}
+
+func newMention(m Mention) RichText {
+	return RichText{Type: RichTextTypeMention, Mention: &m}
+}
+
+const (
+	// MaxRichTextLength is the maximum length of the content of a text run, in UTF-16 code units
+	// as Notion counts them.
+	MaxRichTextLength = 2000
+
+	// MaxRichTextElements is the maximum number of runs in a rich text array.
+	MaxRichTextElements = 100
+)
+
+// NewTexts is like NewText, but splits content longer than MaxRichTextLength into several runs.
+func NewTexts(content string) []RichText {
+	return SplitRichText([]RichText{NewText(content)})
+}
+
+// SplitRichText returns the runs split so that none is longer than MaxRichTextLength, as Notion
+// rejects longer runs. Adjacent text runs with the same annotations and link are joined first, to
+// keep the number of runs low. Split runs keep their annotations and link, and are only cut
+// between characters. Mentions and equations are left as is.
+func SplitRichText(rts []RichText) []RichText {
+	if rts == nil {
+		return nil
+	}
+
+	ret := make([]RichText, 0, len(rts))
+	for _, rt := range joinRichText(rts) {
+		if rt.Text == nil || utf16Len(rt.Text.Content) <= MaxRichTextLength {
+			ret = append(ret, rt)
+			continue
+		}
+
+		for _, chunk := range splitUTF16(rt.Text.Content, MaxRichTextLength) {
+			part := rt
+			text := *rt.Text
+			text.Content = chunk
+			part.Text = &text
+			if part.PlainText != "" {
+				part.PlainText = chunk
+			}
+			ret = append(ret, part)
+		}
+	}
+
+	return ret
+}
+
+// joinRichText joins adjacent text runs with the same formatting.
+func joinRichText(rts []RichText) []RichText {
+	ret := make([]RichText, 0, len(rts))
+	for _, rt := range rts {
+		if n := len(ret); n > 0 && sameFormatting(ret[n-1], rt) {
+			last := &ret[n-1]
+			text := *last.Text
+			text.Content += rt.Text.Content
+			last.Text = &text
+			if last.PlainText != "" || rt.PlainText != "" {
+				last.PlainText = text.Content
+			}
+			continue
+		}
+		ret = append(ret, rt)
+	}
+
+	return ret
+}
+
+func sameFormatting(a, b RichText) bool {
+	if a.Text == nil || b.Text == nil || a.Type != b.Type || a.Href != b.Href {
+		return false
+	}
+
+	return reflect.DeepEqual(a.Annotations, b.Annotations) && reflect.DeepEqual(a.Text.Link, b.Text.Link)
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeUTF16Len(r)
+	}
+
+	return n
+}
+
+func runeUTF16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+
+	return 1
+}
+
+// splitUTF16 cuts s into chunks of at most max UTF-16 code units, between runes.
+func splitUTF16(s string, max int) []string {
+	var ret []string
+
+	start, n := 0, 0
+	for i, r := range s {
+		l := runeUTF16Len(r)
+		if n+l > max {
+			ret = append(ret, s[start:i])
+			start, n = i, 0
+		}
+		n += l
+	}
+
+	return append(ret, s[start:])
+}
+
+// SplitProperties returns a copy of the property values with their title and rich text split by
+// SplitRichText. It fails if a value still has more than MaxRichTextElements runs, since a
+// property value cannot be spread over several requests.
+func SplitProperties(props map[string]ValueProperty) (map[string]ValueProperty, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	ret := make(map[string]ValueProperty, len(props))
+	for name, vp := range props {
+		vp.Title = SplitRichText(vp.Title)
+		vp.RichText = SplitRichText(vp.RichText)
+
+		if n := len(vp.Title) + len(vp.RichText); n > MaxRichTextElements {
+			return nil, fmt.Errorf("property %q has %d rich text elements, the limit is %d", name, n, MaxRichTextElements)
+		}
+
+		ret[name] = vp
+	}
+
+	return ret, nil
+}
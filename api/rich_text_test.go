@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPlainText(t *testing.T) {
+	rts := []RichText{
+		{Type: RichTextTypeText, Text: &Text{Content: "ignored"}, PlainText: "Hello "},
+		NewText("world"),
+		NewText(", "),
+		NewEquation("x^2"),
+		NewUserMention("user-1"),
+	}
+	if got := PlainText(rts); got != "Hello world, x^2" {
+		t.Errorf("expected %q, got %q", "Hello world, x^2", got)
+	}
+	if got := PlainText(nil); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
+
+func TestRichTextBuilders_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   RichText
+		want string
+	}{
+		{"text", NewText("hi"), `{"type":"text","text":{"content":"hi"}}`},
+		{"link", NewLink("docs", "https://example.com"), `{"type":"text","text":{"content":"docs","link":{"url":"https://example.com"}}}`},
+		{"equation", NewEquation("e=mc^2"), `{"type":"equation","equation":{"expression":"e=mc^2"}}`},
+		{"user", NewUserMention("user-1"), `{"type":"mention","mention":{"type":"user","user":{"object":"user","id":"user-1"}}}`},
+		{"page", NewPageMention("page-1"), `{"type":"mention","mention":{"type":"page","page":{"id":"page-1"}}}`},
+		{"database", NewDatabaseMention("db-1"), `{"type":"mention","mention":{"type":"database","database":{"id":"db-1"}}}`},
+		{"date", NewDateMention(DateRange{Start: "2025-01-01"}), `{"type":"mention","mention":{"type":"date","date":{"start":"2025-01-01"}}}`},
+		{"annotated", NewText("b").WithAnnotations(Annotation{Bold: true, Color: ColorRed}), `{"type":"text","text":{"content":"b"},"annotations":{"bold":true,"color":"red"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.rt)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestRichText_MentionsUnmarshal(t *testing.T) {
+	data := []byte(`[
+		{"type":"mention","mention":{"type":"page","page":{"id":"page-1"}},"plain_text":"Roadmap","href":"https://www.notion.so/page1"},
+		{"type":"mention","mention":{"type":"link_preview","link_preview":{"url":"https://github.com/x/y/pull/1"}},"plain_text":"https://github.com/x/y/pull/1"},
+		{"type":"mention","mention":{"type":"link_mention","link_mention":{"href":"https://example.com","title":"Example"}},"plain_text":"Example"},
+		{"type":"mention","mention":{"type":"template_mention","template_mention":{"type":"template_mention_date","template_mention_date":"today"}},"plain_text":"@Today"},
+		{"type":"equation","equation":{"expression":"\\sqrt{2}"},"plain_text":"\\sqrt{2}"}
+	]`)
+	var rts []RichText
+	if err := json.Unmarshal(data, &rts); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if len(rts) != 5 {
+		t.Fatalf("expected 5 runs, got %d", len(rts))
+	}
+	if rts[0].Mention == nil || rts[0].Mention.Page == nil || rts[0].Mention.Page.ID != "page-1" {
+		t.Errorf("unexpected page mention: %+v", rts[0].Mention)
+	}
+	if rts[0].Href != "https://www.notion.so/page1" {
+		t.Errorf("unexpected Href: %q", rts[0].Href)
+	}
+	if rts[1].Mention == nil || rts[1].Mention.LinkPreview == nil || rts[1].Mention.LinkPreview.URL != "https://github.com/x/y/pull/1" {
+		t.Errorf("unexpected link preview mention: %+v", rts[1].Mention)
+	}
+	if rts[2].Mention == nil || rts[2].Mention.LinkMention == nil || rts[2].Mention.LinkMention.Title != "Example" {
+		t.Errorf("unexpected link mention: %+v", rts[2].Mention)
+	}
+	tm := rts[3].Mention
+	if tm == nil || tm.TemplateMention == nil || tm.TemplateMention.TemplateMentionDate != "today" {
+		t.Errorf("unexpected template mention: %+v", tm)
+	}
+	if rts[4].Type != RichTextTypeEquation || rts[4].Equation == nil || rts[4].Equation.Expression != `\sqrt{2}` {
+		t.Errorf("unexpected equation: %+v", rts[4])
+	}
+}
+
+func TestSplitRichText(t *testing.T) {
+	bold := Annotation{Bold: true}
+	long := strings.Repeat("a", 1999) + "😀" + strings.Repeat("é", 2001)
+
+	got := SplitRichText([]RichText{
+		NewLink(long[:1000], "https://example.com").WithAnnotations(bold),
+		NewLink(long[1000:], "https://example.com").WithAnnotations(bold),
+		NewUserMention("user-1"),
+		NewText("short"),
+	})
+
+	if len(got) != 5 {
+		t.Fatalf("expected 5 runs, got %d", len(got))
+	}
+	for i, rt := range got[:3] {
+		if rt.Annotations == nil || !rt.Annotations.Bold || rt.Text.Link == nil || rt.Text.Link.URL != "https://example.com" {
+			t.Errorf("run %d lost its formatting: %+v", i, rt)
+		}
+		if !utf8.ValidString(rt.Text.Content) || utf16Len(rt.Text.Content) > MaxRichTextLength {
+			t.Errorf("run %d is invalid or too long: %d UTF-16 code units", i, utf16Len(rt.Text.Content))
+		}
+	}
+	if got[0].Text.Content != strings.Repeat("a", 1999) {
+		t.Errorf("expected the emoji not to be cut, got a first run of %d bytes", len(got[0].Text.Content))
+	}
+	if PlainText(got[:3]) != long {
+		t.Error("expected the split runs to keep the content")
+	}
+	if got[3].Mention == nil || got[4].Text.Content != "short" {
+		t.Errorf("unexpected runs %+v", got[3:])
+	}
+
+	if SplitRichText(nil) != nil {
+		t.Error("expected nil for nil")
+	}
+	if got := NewTexts(strings.Repeat("x", 4001)); len(got) != 3 {
+		t.Errorf("expected 3 runs, got %d", len(got))
+	}
+}
+
+func TestSplitProperties(t *testing.T) {
+	props := map[string]ValueProperty{
+		"Name":  {Type: ValuePropertyTypeTitle, Title: []RichText{NewText(strings.Repeat("x", 2500))}},
+		"Pages": {Type: ValuePropertyTypeNumber, Number: Ptr(1.0)},
+	}
+
+	got, err := SplitProperties(props)
+	if err != nil {
+		t.Fatalf("SplitProperties failed: %v", err)
+	}
+	if len(got["Name"].Title) != 2 || *got["Pages"].Number != 1 {
+		t.Errorf("unexpected result %+v", got)
+	}
+	if len(props["Name"].Title) != 1 {
+		t.Error("expected the input not to be modified")
+	}
+
+	var many []RichText
+	for i := 0; i < 101; i++ {
+		many = append(many, NewText("x"), NewUserMention("user-1"))
+	}
+	_, err = SplitProperties(map[string]ValueProperty{"Notes": {Type: ValuePropertyTypeRichText, RichText: many}})
+	if err == nil || err.Error() != `property "Notes" has 202 rich text elements, the limit is 100` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// TimestampLayout is the layout of the timestamps returned by Notion, e.g.
+	// "2020-03-17T19:10:04.968Z".
+	TimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+	// DateLayout is the layout of date only values, e.g. "2020-12-08".
+	DateLayout = "2006-01-02"
+
+	// localDateTimeLayout is the layout of date times without an offset, which are interpreted in
+	// the time_zone of the date.
+	localDateTimeLayout = "2006-01-02T15:04:05.000"
+)
+
+// ParseTimestamp parses an ISO 8601 date time string as returned by Notion.
+func ParseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing timestamp %q: %w", s, err)
+	}
+
+	return t, nil
+}
+
+// FormatTimestamp formats the time in UTC in the format used by Notion.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(TimestampLayout)
+}
+
+// CreatedAt returns CreatedTime as a time.Time.
+func (c CommonObject) CreatedAt() (time.Time, error) {
+	return ParseTimestamp(c.CreatedTime)
+}
+
+// LastEditedAt returns LastEditedTime as a time.Time.
+func (c CommonObject) LastEditedAt() (time.Time, error) {
+	return ParseTimestamp(c.LastEditedTime)
+}
+
+// ExpiresAt returns ExpiryTime as a time.Time.
+func (f NotionFile) ExpiresAt() (time.Time, error) {
+	return ParseTimestamp(f.ExpiryTime)
+}
+
+// ParseDate parses a date as found in the start or end of a date property. The value is either a
+// date, e.g. "2020-12-08", or a date time, e.g. "2020-12-08T12:00:00.000+02:00". hasTime reports
+// which of the two it was.
+//
+// Values without an offset, which are dates and the date times of dates with a time zone, are
+// interpreted in the IANA time zone tz, or in UTC if tz is empty.
+func ParseDate(s, tz string) (t time.Time, hasTime bool, err error) {
+	loc := time.UTC
+	if tz != "" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("loading time zone %q: %w", tz, err)
+		}
+	}
+
+	return ParseDateIn(s, loc)
+}
+
+// ParseDateIn is like ParseDate, but interprets values without an offset in loc, which can be any
+// location, e.g. one made with time.FixedZone.
+func ParseDateIn(s string, loc *time.Location) (t time.Time, hasTime bool, err error) {
+	if len(s) == len(DateLayout) {
+		t, err = time.ParseInLocation(DateLayout, s, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("parsing date %q: %w", s, err)
+		}
+
+		return t, false, nil
+	}
+
+	if t, err = time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.In(loc), true, nil
+	}
+
+	t, err = time.ParseInLocation("2006-01-02T15:04:05.999999999", s, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("parsing date %q: %w", s, err)
+	}
+
+	return t, true, nil
+}
+
+// StartTime returns the start of the date. hasTime is false if the start is a date without a
+// time.
+func (d DateRange) StartTime() (t time.Time, hasTime bool, err error) {
+	return ParseDate(d.Start, d.TimeZone)
+}
+
+// EndTime returns the end of the date. The zero time is returned if the date is not a range.
+func (d DateRange) EndTime() (t time.Time, hasTime bool, err error) {
+	if d.End == "" {
+		return time.Time{}, false, nil
+	}
+
+	return ParseDate(d.End, d.TimeZone)
+}
+
+// IsRange reports whether the date has an end.
+func (d DateRange) IsRange() bool {
+	return d.End != ""
+}
+
+// NewDate returns a date without a time, on the day of t in its location.
+func NewDate(t time.Time) DateRange {
+	return DateRange{Start: t.Format(DateLayout)}
+}
+
+// NewDateTime returns a date with a time. The offset of t is kept.
+func NewDateTime(t time.Time) DateRange {
+	return DateRange{Start: t.Format(TimestampLayout)}
+}
+
+// NewDateTimeIn returns a date with a time in the IANA time zone of loc. Notion shows the date
+// in that time zone, rather than in the time zone of the user. Notion only accepts IANA names, so
+// for other locations, e.g. time.Local or a time.FixedZone, the date is written with the offset
+// of loc instead, like NewDateTime, and no time zone is sent.
+func NewDateTimeIn(t time.Time, loc *time.Location) DateRange {
+	t = t.In(loc)
+	if !isIANAZone(t) {
+		return NewDateTime(t)
+	}
+
+	return DateRange{Start: t.Format(localDateTimeLayout), TimeZone: loc.String()}
+}
+
+// isIANAZone reports whether the location of t is known by its name in the time zone database,
+// with the same offset at t.
+func isIANAZone(t time.Time) bool {
+	name := t.Location().String()
+	if name == "" || name == "Local" {
+		return false
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return false
+	}
+
+	_, want := t.Zone()
+	_, got := t.In(loc).Zone()
+	return got == want
+}
+
+// NewDateRange returns a range from start to end. The range is made of dates if withTime is
+// false, and of date times otherwise.
+func NewDateRange(start, end time.Time, withTime bool) DateRange {
+	if !withTime {
+		return DateRange{Start: start.Format(DateLayout), End: end.Format(DateLayout)}
+	}
+
+	return DateRange{Start: start.Format(TimestampLayout), End: end.Format(TimestampLayout)}
+}
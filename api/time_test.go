@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCommonObject_Timestamps(t *testing.T) {
+	data := []byte(`{"object":"page","id":"p1","created_time":"2020-03-17T19:10:04.968Z","last_edited_time":"2020-03-17T21:49:37.913Z"}`)
+
+	var pg Page
+	if err := json.Unmarshal(data, &pg); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	created, err := pg.CreatedAt()
+	if err != nil {
+		t.Fatalf("CreatedAt failed: %v", err)
+	}
+	if want := time.Date(2020, 3, 17, 19, 10, 4, 968000000, time.UTC); !created.Equal(want) {
+		t.Errorf("expected %v, got %v", want, created)
+	}
+
+	edited, err := pg.LastEditedAt()
+	if err != nil {
+		t.Fatalf("LastEditedAt failed: %v", err)
+	}
+	if got := FormatTimestamp(edited); got != pg.LastEditedTime {
+		t.Errorf("expected %q, got %q", pg.LastEditedTime, got)
+	}
+
+	if _, err := (CommonObject{}).CreatedAt(); err == nil {
+		t.Error("expected error for empty created time")
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	got := FormatTimestamp(time.Date(2020, 12, 8, 14, 0, 0, 0, loc))
+	if got != "2020-12-08T12:00:00.000Z" {
+		t.Errorf("expected %q, got %q", "2020-12-08T12:00:00.000Z", got)
+	}
+}
+
+func TestParseDateIn(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	got, hasTime, err := ParseDateIn("2020-12-08", loc)
+	if err != nil {
+		t.Fatalf("ParseDateIn failed: %v", err)
+	}
+	if want := time.Date(2020, 12, 8, 0, 0, 0, 0, loc); !got.Equal(want) || hasTime {
+		t.Errorf("expected %v without time, got %v (hasTime %v)", want, got, hasTime)
+	}
+
+	got, hasTime, err = ParseDateIn("2020-12-08T12:00:00.000", loc)
+	if err != nil {
+		t.Fatalf("ParseDateIn failed: %v", err)
+	}
+	if want := time.Date(2020, 12, 8, 10, 0, 0, 0, time.UTC); !got.Equal(want) || !hasTime {
+		t.Errorf("expected %v with time, got %v (hasTime %v)", want, got, hasTime)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		s, tz       string
+		want        time.Time
+		wantHasTime bool
+	}{
+		{"date", "2020-12-08", "", time.Date(2020, 12, 8, 0, 0, 0, 0, time.UTC), false},
+		{"date in time zone", "2020-12-08", "America/New_York", time.Date(2020, 12, 8, 0, 0, 0, 0, ny), false},
+		{"utc date time", "2020-12-08T12:00:00.000Z", "", time.Date(2020, 12, 8, 12, 0, 0, 0, time.UTC), true},
+		{"date time with offset", "2020-12-08T12:00:00.000+02:00", "", time.Date(2020, 12, 8, 10, 0, 0, 0, time.UTC), true},
+		{"local date time", "2020-12-08T12:00:00.000", "America/New_York", time.Date(2020, 12, 8, 12, 0, 0, 0, ny), true},
+		{"local date time without millis", "2020-12-08T12:00:00", "America/New_York", time.Date(2020, 12, 8, 12, 0, 0, 0, ny), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasTime, err := ParseDate(tt.s, tt.tz)
+			if err != nil {
+				t.Fatalf("ParseDate failed: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if hasTime != tt.wantHasTime {
+				t.Errorf("expected hasTime %v, got %v", tt.wantHasTime, hasTime)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "08/12/2020", "2020-12-08T25:00:00Z"} {
+		if _, _, err := ParseDate(s, ""); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+	if _, _, err := ParseDate("2020-12-08", "Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for unknown time zone")
+	}
+}
+
+func TestDateRange_Times(t *testing.T) {
+	d := DateRange{Start: "2020-12-08", End: "2020-12-10"}
+	if !d.IsRange() {
+		t.Error("expected a range")
+	}
+	end, hasTime, err := d.EndTime()
+	if err != nil {
+		t.Fatalf("EndTime failed: %v", err)
+	}
+	if hasTime || !end.Equal(time.Date(2020, 12, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected end %v (hasTime %v)", end, hasTime)
+	}
+
+	single := DateRange{Start: "2020-12-08T12:00:00.000Z"}
+	if single.IsRange() {
+		t.Error("expected a single date")
+	}
+	if end, _, err := single.EndTime(); err != nil || !end.IsZero() {
+		t.Errorf("expected zero end time, got %v (err %v)", end, err)
+	}
+}
+
+func TestNewDateTimeIn_Local(t *testing.T) {
+	d := NewDateTimeIn(time.Date(2020, 12, 8, 12, 0, 0, 0, time.UTC), time.Local)
+	if d.TimeZone != "" {
+		t.Errorf("expected no time zone for time.Local, got %q", d.TimeZone)
+	}
+	if got, _, err := d.StartTime(); err != nil || !got.Equal(time.Date(2020, 12, 8, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start %v, error %v", got, err)
+	}
+}
+
+func TestDateConstructors_JSON(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	start := time.Date(2020, 12, 8, 12, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+
+	tests := []struct {
+		name string
+		d    DateRange
+		want string
+	}{
+		{"date", NewDate(start), `{"start":"2020-12-08"}`},
+		{"date time", NewDateTime(start), `{"start":"2020-12-08T12:00:00.000Z"}`},
+		{"date time in", NewDateTimeIn(start, ny), `{"start":"2020-12-08T07:00:00.000","time_zone":"America/New_York"}`},
+		{"date time in a fixed zone", NewDateTimeIn(start, time.FixedZone("UTC+2", 2*60*60)), `{"start":"2020-12-08T14:00:00.000+02:00"}`},
+		{"date time in a fixed zone named like a time zone", NewDateTimeIn(start, time.FixedZone("EST", 2*60*60)), `{"start":"2020-12-08T14:00:00.000+02:00"}`},
+		{"date range", NewDateRange(start, end, false), `{"start":"2020-12-08","end":"2020-12-10"}`},
+		{"date time range", NewDateRange(start, end, true), `{"start":"2020-12-08T12:00:00.000Z","end":"2020-12-10T12:00:00.000Z"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.d)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, b)
+			}
+
+			got, hasTime, err := tt.d.StartTime()
+			if err != nil {
+				t.Fatalf("StartTime failed: %v", err)
+			}
+			if !hasTime {
+				got = got.Add(12 * time.Hour)
+			}
+			if !got.Equal(start) {
+				t.Errorf("expected start %v, got %v", start, got)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package api
 
+import (
+	"encoding/json"
+
+	"github.com/surajssd/libnotion/internal/rawjson"
+)
+
 // Response returned by the Notion API when the status code is 200.
 type Response struct {
 	Object     string `json:"object,omitempty"`
@@ -27,7 +33,6 @@ type DatabaseResponseList struct {
 }
 
 type CommonObject struct {
-	// TODO: Convert to time.Time type.
 	// Date and time when this database was created. Formatted as an ISO 8601 date time string. e.g. "2020-03-17T19:10:04.968Z"
 	CreatedTime string `json:"created_time,omitempty"`
 	// Date and time when this database was updated. Formatted as an ISO 8601 date time string. e.g. "2020-03-17T21:49:37.913Z"
@@ -108,11 +113,21 @@ type Database struct {
 	CommonObject `json:",inline"`
 
 	// Title of database as it appears in Notion. An array of rich text objects.
-	Title []Title `json:"title,omitempty"`
+	Title []RichText `json:"title,omitempty"`
 
 	// Property schema of database. This corresponds with the columns in the database. The keys are
 	// the names of properties as they appear in Notion and the values are property schema objects.
 	Properties map[string]Property `json:"properties,omitempty"`
+
+	// Data sources of the database. Since API version 2025-09-03 the property schema is part of the
+	// data sources instead of the database.
+	DataSources []DataSourceReference `json:"data_sources,omitempty"`
+}
+
+// DataSourceReference identifies a data source of a database.
+type DataSourceReference struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 // PageResponseList is used to parse the response when querying pages endpoint.
@@ -136,6 +151,17 @@ type Page struct {
 	// Note: This setting doesn't affect the ability to update the page using the API.
 	IsLocked bool `json:"is_locked,omitempty"`
 
+	// Page icon. Can be an emoji, an external image or an uploaded file.
+	Icon *Icon `json:"icon,omitempty"`
+
+	// Page cover image. Can be an external image or an uploaded file.
+	Cover *File `json:"cover,omitempty"`
+
+	// Template of the data source applied to the page. Only used when creating a page, and it
+	// cannot be combined with content blocks. Notion applies the template after the page is
+	// returned.
+	Template *PageTemplate `json:"template,omitempty"`
+
 	// Property values of this page. If parent.type is "page_id" or "workspace", then the only valid
 	// key is title. If parent.type is "database_id", then the keys and values of this field are
 	// determined by the properties of the database this page belongs to.
@@ -145,16 +171,35 @@ type Page struct {
 	Properties map[string]ValueProperty `json:"properties,omitempty"`
 }
 
-// Title of database as it appears in Notion.
-// TODO: Rename this to RichText.
-type Title struct {
-	Type        string     `json:"type,omitempty"`
-	Text        Text       `json:"text,omitempty"`
-	Annotations Annotation `json:"annotations,omitempty"`
-	PlainText   string     `json:"plain_text,omitempty"`
-	Href        string     `json:"href,omitempty"`
+// RichText is a run of rich text, used by page titles and text properties, database titles, blocks
+// and comments. Type is one of "text", "mention" or "equation" and the matching field is set.
+type RichText struct {
+	Type        RichTextType `json:"type,omitempty"`
+	Text        *Text        `json:"text,omitempty"`
+	Mention     *Mention     `json:"mention,omitempty"`
+	Equation    *Equation    `json:"equation,omitempty"`
+	Annotations *Annotation  `json:"annotations,omitempty"`
+
+	// The text without annotations. Set by Notion when reading, ignored when writing.
+	PlainText string `json:"plain_text,omitempty"`
+
+	// The URL of any link or mention in this text.
+	Href string `json:"href,omitempty"`
 }
 
+// Title of database as it appears in Notion.
+//
+// Deprecated: Use RichText, Title is the same type.
+type Title = RichText
+
+type RichTextType string
+
+var (
+	RichTextTypeText     = RichTextType("text")
+	RichTextTypeMention  = RichTextType("mention")
+	RichTextTypeEquation = RichTextType("equation")
+)
+
 type Text struct {
 	// Text content. This field contains the actual content of your text and is probably the field
 	// you'll use most often.
@@ -168,6 +213,62 @@ type Link struct {
 	URL string `json:"url,omitempty"`
 }
 
+// Equation is an inline KaTeX equation.
+type Equation struct {
+	Expression string `json:"expression,omitempty"`
+}
+
+// Mention is an inline mention of a user, page, database, date, link or template variable. Type
+// is one of the MentionType values and the matching field is set.
+type Mention struct {
+	Type            MentionType      `json:"type,omitempty"`
+	User            *User            `json:"user,omitempty"`
+	Page            *MentionRef      `json:"page,omitempty"`
+	Database        *MentionRef      `json:"database,omitempty"`
+	Date            *DateRange       `json:"date,omitempty"`
+	LinkPreview     *Link            `json:"link_preview,omitempty"`
+	LinkMention     *LinkMention     `json:"link_mention,omitempty"`
+	TemplateMention *TemplateMention `json:"template_mention,omitempty"`
+	CustomEmoji     *CustomEmoji     `json:"custom_emoji,omitempty"`
+}
+
+type MentionType string
+
+var (
+	MentionTypeUser            = MentionType("user")
+	MentionTypePage            = MentionType("page")
+	MentionTypeDatabase        = MentionType("database")
+	MentionTypeDate            = MentionType("date")
+	MentionTypeLinkPreview     = MentionType("link_preview")
+	MentionTypeLinkMention     = MentionType("link_mention")
+	MentionTypeTemplateMention = MentionType("template_mention")
+	MentionTypeCustomEmoji     = MentionType("custom_emoji")
+)
+
+// MentionRef is the ID of a mentioned page or database.
+type MentionRef struct {
+	ID string `json:"id,omitempty"`
+}
+
+// LinkMention is a mention of a web page with the metadata Notion fetched for it.
+type LinkMention struct {
+	Href         string `json:"href,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Description  string `json:"description,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	LinkAuthor   string `json:"link_author,omitempty"`
+	LinkProvider string `json:"link_provider,omitempty"`
+}
+
+// TemplateMention is a variable in a template that is filled in when the template is used. Type
+// is either "template_mention_date" ("today" or "now") or "template_mention_user" ("me").
+type TemplateMention struct {
+	Type                string `json:"type,omitempty"`
+	TemplateMentionDate string `json:"template_mention_date,omitempty"`
+	TemplateMentionUser string `json:"template_mention_user,omitempty"`
+}
+
 type Annotation struct {
 	// Whether the text is bolded.
 	Bold bool `json:"bold,omitempty"`
@@ -207,17 +308,80 @@ var (
 	ColorRedBackground    = Color("red_background")
 )
 
+// Property is a database property schema object. It describes a single column of a database or
+// data source. Only the configuration matching Type is populated.
 type Property struct {
-	ID          string   `json:"id,omitempty"`
-	Type        string   `json:"type,omitempty"`
-	MultiSelect Select   `json:"multi_select,omitempty"`
-	Number      Number   `json:"number,omitempty"`
-	Select      Select   `json:"select,omitempty"`
-	Date        Date     `json:"date,omitempty"`
-	Checkbox    Checkbox `json:"checkbox,omitempty"`
-	Status      Status   `json:"status,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+
+	// Description of the property as it appears in Notion.
+	Description string `json:"description,omitempty"`
+
+	Title          *EmptyConfig    `json:"title,omitempty"`
+	RichText       *EmptyConfig    `json:"rich_text,omitempty"`
+	Number         *Number         `json:"number,omitempty"`
+	Select         *Select         `json:"select,omitempty"`
+	MultiSelect    *Select         `json:"multi_select,omitempty"`
+	Status         *Status         `json:"status,omitempty"`
+	Date           *Date           `json:"date,omitempty"`
+	People         *EmptyConfig    `json:"people,omitempty"`
+	Files          *EmptyConfig    `json:"files,omitempty"`
+	Checkbox       *Checkbox       `json:"checkbox,omitempty"`
+	URL            *EmptyConfig    `json:"url,omitempty"`
+	Email          *EmptyConfig    `json:"email,omitempty"`
+	PhoneNumber    *EmptyConfig    `json:"phone_number,omitempty"`
+	Formula        *FormulaConfig  `json:"formula,omitempty"`
+	Relation       *RelationConfig `json:"relation,omitempty"`
+	Rollup         *RollupConfig   `json:"rollup,omitempty"`
+	CreatedTime    *EmptyConfig    `json:"created_time,omitempty"`
+	CreatedBy      *EmptyConfig    `json:"created_by,omitempty"`
+	LastEditedTime *EmptyConfig    `json:"last_edited_time,omitempty"`
+	LastEditedBy   *EmptyConfig    `json:"last_edited_by,omitempty"`
+	UniqueID       *UniqueIDConfig `json:"unique_id,omitempty"`
+	Verification   *EmptyConfig    `json:"verification,omitempty"`
+	Button         *EmptyConfig    `json:"button,omitempty"`
+
+	// Fields of the property schema that this library does not know about, e.g. the configuration
+	// of property types added to Notion later. They are kept as is and written back when marshalled.
+	Unknown map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the property schema and keeps the fields it does not know about.
+func (p *Property) UnmarshalJSON(data []byte) error {
+	type property Property
+	var prop property
+	if err := json.Unmarshal(data, &prop); err != nil {
+		return err
+	}
+
+	unknown, err := rawjson.Unknown(data, prop)
+	if err != nil {
+		return err
+	}
+
+	*p = Property(prop)
+	p.Unknown = unknown
+
+	return nil
+}
+
+// MarshalJSON encodes the property schema including the fields it does not know about.
+func (p Property) MarshalJSON() ([]byte, error) {
+	type property Property
+	data, err := json.Marshal(property(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return rawjson.Merge(data, p.Unknown)
 }
 
+// EmptyConfig is used by database property schema objects that have no additional configuration,
+// e.g. title, rich_text, people, files, url, email, phone_number and the created/last edited
+// time and by properties.
+type EmptyConfig struct{}
+
 // Status property configuration for databases.
 type Status struct {
 	Options []StatusOption `json:"options,omitempty"`
@@ -256,6 +420,8 @@ type Option struct {
 }
 
 type Number struct {
+	// How the number is displayed in Notion, e.g. "number", "number_with_commas", "percent",
+	// "dollar", "euro".
 	Format string `json:"format,omitempty"`
 }
 
@@ -266,6 +432,65 @@ type Date struct{}
 // property.
 type Checkbox struct{}
 
+// FormulaConfig is the schema of a formula database property.
+type FormulaConfig struct {
+	// The formula that is used to compute the values for this property.
+	Expression string `json:"expression,omitempty"`
+}
+
+// RelationConfig is the schema of a relation database property.
+type RelationConfig struct {
+	// The data source that the relation refers to.
+	DataSourceID string `json:"data_source_id,omitempty"`
+
+	// The database that the relation refers to.
+	DatabaseID string `json:"database_id,omitempty"`
+
+	// Type of the relation. Either "single_property" or "dual_property".
+	Type RelationType `json:"type,omitempty"`
+
+	// Present if the relation is only visible from this data source.
+	SingleProperty *EmptyConfig `json:"single_property,omitempty"`
+
+	// Present if the relation is synced with a property in the related data source.
+	DualProperty *DualProperty `json:"dual_property,omitempty"`
+}
+
+type RelationType string
+
+var (
+	RelationTypeSingleProperty = RelationType("single_property")
+	RelationTypeDualProperty   = RelationType("dual_property")
+)
+
+// DualProperty describes the property in the related data source that a dual relation is synced
+// with.
+type DualProperty struct {
+	SyncedPropertyName string `json:"synced_property_name,omitempty"`
+	SyncedPropertyID   string `json:"synced_property_id,omitempty"`
+}
+
+// RollupConfig is the schema of a rollup database property.
+type RollupConfig struct {
+	// The name and ID of the relation property this rollup is based on.
+	RelationPropertyName string `json:"relation_property_name,omitempty"`
+	RelationPropertyID   string `json:"relation_property_id,omitempty"`
+
+	// The name and ID of the property of the related pages that is rolled up.
+	RollupPropertyName string `json:"rollup_property_name,omitempty"`
+	RollupPropertyID   string `json:"rollup_property_id,omitempty"`
+
+	// The function that computes the rollup value from the related pages. e.g. "count", "sum",
+	// "show_original".
+	Function string `json:"function,omitempty"`
+}
+
+// UniqueIDConfig is the schema of a unique_id database property.
+type UniqueIDConfig struct {
+	// Common prefix of the generated IDs, e.g. "TASK". Nil if the IDs have no prefix.
+	Prefix *string `json:"prefix"`
+}
+
 type Parent struct {
 	// The parent type could be page, database, data_source or workspace.
 	Type ParentType `json:"type,omitempty"`
@@ -279,6 +504,9 @@ type Parent struct {
 	// The ID of the page that this page belongs to.
 	PageID string `json:"page_id,omitempty"`
 
+	// The ID of the block that this object belongs to. Used by comments on blocks.
+	BlockID string `json:"block_id,omitempty"`
+
 	// True if the parent is a workspace.
 	Workspace bool `json:"workspace,omitempty"`
 }
@@ -295,29 +523,268 @@ var (
 	// If the parent is a page.
 	ParentTypePage = ParentType("page_id")
 
+	// If the parent is a block.
+	ParentTypeBlock = ParentType("block_id")
+
 	// A page with a workspace parent is a top-level page within a Notion workspace.
 	ParentTypeWorkspace = ParentType("workspace")
 )
 
+// ValueProperty is the value of a single page property. When marshalled, the value matching Type
+// is always written, even if it is the zero value: a nil Number, Date, Select or Status and an
+// empty URL, Email or PhoneNumber are sent as null, which clears the value, while 0 and false are
+// sent as is. To leave a property unchanged on update, leave it out of the properties map.
 type ValueProperty struct {
-	ID          string            `json:"id,omitempty"`
-	Type        ValuePropertyType `json:"type"` // compulsory field.
-	MultiSelect []Option          `json:"multi_select,omitempty"`
-	Number      float64           `json:"number,omitempty"`
-	Date        *DateRange        `json:"date,omitempty"`
-	Checkbox    bool              `json:"checkbox,omitempty"`
-	Select      *Option           `json:"select,omitempty"`
-	Title       []Title           `json:"title,omitempty"`
-	Relation    []Relation        `json:"relation,omitempty"`
-	URL         string            `json:"url,omitempty"`
-	RichText    []Title           `json:"rich_text,omitempty"`
-	Status      *Option           `json:"status,omitempty"`
+	ID             string             `json:"id,omitempty"`
+	Type           ValuePropertyType  `json:"type"` // compulsory field.
+	MultiSelect    []Option           `json:"multi_select,omitempty"`
+	Number         *float64           `json:"number,omitempty"`
+	Date           *DateRange         `json:"date,omitempty"`
+	Checkbox       bool               `json:"checkbox,omitempty"`
+	Select         *Option            `json:"select,omitempty"`
+	Title          []RichText         `json:"title,omitempty"`
+	Relation       []Relation         `json:"relation,omitempty"`
+	URL            string             `json:"url,omitempty"`
+	RichText       []RichText         `json:"rich_text,omitempty"`
+	Status         *Option            `json:"status,omitempty"`
+	People         []User             `json:"people,omitempty"`
+	Files          []File             `json:"files,omitempty"`
+	Email          string             `json:"email,omitempty"`
+	PhoneNumber    string             `json:"phone_number,omitempty"`
+	Formula        *FormulaValue      `json:"formula,omitempty"`
+	Rollup         *RollupValue       `json:"rollup,omitempty"`
+	CreatedTime    string             `json:"created_time,omitempty"`
+	CreatedBy      *User              `json:"created_by,omitempty"`
+	LastEditedTime string             `json:"last_edited_time,omitempty"`
+	LastEditedBy   *User              `json:"last_edited_by,omitempty"`
+	UniqueID       *UniqueIDValue     `json:"unique_id,omitempty"`
+	Verification   *VerificationValue `json:"verification,omitempty"`
+	Button         *ButtonValue       `json:"button,omitempty"`
+
+	// True if a relation or people value has more than 25 entries and the page object only lists
+	// the first 25. Use the page property endpoint to get the rest.
+	HasMore bool `json:"has_more,omitempty"`
+
+	// Fields of the property value that this library does not know about, e.g. the values of
+	// property types added to Notion later. They are kept as is and written back when marshalled.
+	Unknown map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the property value and keeps the fields it does not know about.
+func (vp *ValueProperty) UnmarshalJSON(data []byte) error {
+	type valueProperty ValueProperty
+	var v valueProperty
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	unknown, err := rawjson.Unknown(data, v)
+	if err != nil {
+		return err
+	}
+
+	*vp = ValueProperty(v)
+	vp.Unknown = unknown
+
+	return nil
+}
+
+// MarshalJSON writes the property value matching Type explicitly, see ValueProperty, followed by
+// the fields it does not know about.
+func (vp ValueProperty) MarshalJSON() ([]byte, error) {
+	type valueProperty ValueProperty
+	data, err := json.Marshal(valueProperty(vp))
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := vp.writableValue()
+	if !ok {
+		return rawjson.Merge(data, vp.Unknown)
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields[string(vp.Type)], err = json.Marshal(value); err != nil {
+		return nil, err
+	}
+
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+
+	return rawjson.Merge(data, vp.Unknown)
+}
+
+// writableValue returns the value that has to be sent for Type. It returns false for read-only
+// property types and unknown types, whose values are only written when set.
+func (vp ValueProperty) writableValue() (interface{}, bool) {
+	switch vp.Type {
+	case ValuePropertyTypeTitle:
+		return emptyIfNil(vp.Title), true
+	case ValuePropertyTypeRichText:
+		return emptyIfNil(vp.RichText), true
+	case ValuePropertyTypeNumber:
+		return vp.Number, true
+	case ValuePropertyTypeSelect:
+		return vp.Select, true
+	case ValuePropertyTypeMultiSelect:
+		return emptyIfNil(vp.MultiSelect), true
+	case ValuePropertyTypeStatus:
+		return vp.Status, true
+	case ValuePropertyTypeDate:
+		return vp.Date, true
+	case ValuePropertyTypeCheckbox:
+		return vp.Checkbox, true
+	case ValuePropertyTypeURL:
+		return nullIfEmpty(vp.URL), true
+	case ValuePropertyTypeEmail:
+		return nullIfEmpty(vp.Email), true
+	case ValuePropertyTypePhoneNumber:
+		return nullIfEmpty(vp.PhoneNumber), true
+	case ValuePropertyTypeRelation:
+		return emptyIfNil(vp.Relation), true
+	case ValuePropertyTypePeople:
+		return emptyIfNil(vp.People), true
+	case ValuePropertyTypeFiles:
+		return emptyIfNil(vp.Files), true
+	}
+
+	return nil, false
+}
+
+func emptyIfNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// Ptr returns a pointer to v. It is handy to fill optional fields like ValueProperty.Number.
+func Ptr[T any](v T) *T {
+	return &v
 }
 
 type Relation struct {
 	ID string `json:"id,omitempty"`
 }
 
+// File is a file object, as used in the files property value.
+type File struct {
+	// Name of the file.
+	Name string `json:"name,omitempty"`
+
+	// Type of the file. Either "file" for files hosted by Notion or "external".
+	Type string `json:"type,omitempty"`
+
+	// Present if the file is hosted by Notion.
+	File *NotionFile `json:"file,omitempty"`
+
+	// Present if the file is hosted externally.
+	External *External `json:"external,omitempty"`
+
+	// Present if the file was uploaded using the File Upload API. Only used when writing.
+	FileUpload *FileUploadRef `json:"file_upload,omitempty"`
+}
+
+// FileUploadRef references a file uploaded using the File Upload API. Once the upload is complete
+// it can be attached to file blocks, files properties, page icons and covers.
+type FileUploadRef struct {
+	ID string `json:"id"`
+}
+
+// Icon is the icon of a page or a callout block.
+type Icon struct {
+	// Type of the icon. One of "emoji", "external", "file", "file_upload" or "custom_emoji".
+	Type string `json:"type,omitempty"`
+
+	Emoji       string         `json:"emoji,omitempty"`
+	External    *External      `json:"external,omitempty"`
+	File        *NotionFile    `json:"file,omitempty"`
+	FileUpload  *FileUploadRef `json:"file_upload,omitempty"`
+	CustomEmoji *CustomEmoji   `json:"custom_emoji,omitempty"`
+}
+
+// CustomEmoji is a custom emoji of the workspace used as an icon.
+type CustomEmoji struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// NotionFile represents a file hosted by Notion.
+type NotionFile struct {
+	// Authenticated URL of the file. The URL is only valid until ExpiryTime.
+	URL string `json:"url,omitempty"`
+
+	// Date and time when the URL expires. Formatted as an ISO 8601 date time string.
+	ExpiryTime string `json:"expiry_time,omitempty"`
+}
+
+// External represents an externally hosted file.
+type External struct {
+	URL string `json:"url,omitempty"`
+}
+
+// FormulaValue is the result of evaluating a formula property. Only the field matching Type is
+// set, and it is nil when the formula result is empty.
+type FormulaValue struct {
+	// Type of the result. One of "string", "number", "boolean" or "date".
+	Type    string     `json:"type,omitempty"`
+	String  *string    `json:"string,omitempty"`
+	Number  *float64   `json:"number,omitempty"`
+	Boolean *bool      `json:"boolean,omitempty"`
+	Date    *DateRange `json:"date,omitempty"`
+}
+
+// RollupValue is the result of a rollup property. Only the field matching Type is set.
+type RollupValue struct {
+	// Type of the result. One of "number", "date", "array", "unsupported" or "incomplete".
+	Type string `json:"type,omitempty"`
+
+	// The rollup function used to compute the result, e.g. "count" or "show_original".
+	Function string     `json:"function,omitempty"`
+	Number   *float64   `json:"number,omitempty"`
+	Date     *DateRange `json:"date,omitempty"`
+
+	// Property values of the related pages. Each element carries its own type.
+	Array []ValueProperty `json:"array,omitempty"`
+}
+
+// UniqueIDValue is the value of a unique_id property, e.g. "TASK-42".
+type UniqueIDValue struct {
+	// Common prefix of the ID. Nil if the property has no prefix.
+	Prefix *string `json:"prefix"`
+
+	// Auto-incrementing number of the ID.
+	Number int `json:"number"`
+}
+
+// VerificationValue is the value of a verification property of a wiki page.
+type VerificationValue struct {
+	// State of the verification. One of "verified", "expired" or "unverified".
+	State string `json:"state,omitempty"`
+
+	// User who verified the page. Nil if the page is unverified.
+	VerifiedBy *User `json:"verified_by,omitempty"`
+
+	// Date range the verification is valid for. Nil if the page is unverified.
+	Date *DateRange `json:"date,omitempty"`
+}
+
+// ButtonValue is the value of a button property. Buttons carry no data.
+type ButtonValue struct{}
+
 type ValuePropertyType string
 
 var (
@@ -341,6 +808,9 @@ var (
 	ValuePropertyTypeLastEditedTime = ValuePropertyType("last_edited_time")
 	ValuePropertyTypeLastEditedBy   = ValuePropertyType("last_edited_by")
 	ValuePropertyTypeStatus         = ValuePropertyType("status")
+	ValuePropertyTypeUniqueID       = ValuePropertyType("unique_id")
+	ValuePropertyTypeVerification   = ValuePropertyType("verification")
+	ValuePropertyTypeButton         = ValuePropertyType("button")
 )
 
 // DateRange is used to specify span of date between start and end.
@@ -351,6 +821,10 @@ type DateRange struct {
 	// An ISO 8601 formatted date, with optional time. Represents the end of a date range.
 	// If null, this property's date value is not a range. e.g. "2020-12-08T12:00:00Z".
 	End string `json:"end,omitempty"`
+
+	// IANA time zone of the date, e.g. "America/New_York". When set, Start and End are date times
+	// without an offset, in this time zone.
+	TimeZone string `json:"time_zone,omitempty"`
 }
 
 type SortDirection string
@@ -389,28 +863,64 @@ type QueryDB struct {
 	StartCursor string `json:"start_cursor,omitempty"`
 }
 
-type Filter struct {
+// Filter is the filter of a database query. It is either a property or timestamp condition, or a
+// compound "and" / "or" of filters. Compound filters can be nested up to two levels deep.
+//
+// Filter used to be a separate type with only Or and And, it is kept as an alias so that existing
+// filters keep compiling.
+type Filter = PropertyFilter
+
+// PropertyFilter is a single node of a filter. Exactly one of the following must be set:
+//   - Or or And, to combine other filters.
+//   - Property and one of the property type conditions, e.g. Select.
+//   - Timestamp and the matching condition, CreatedTime or LastEditedTime.
+//
+// Use Validate to check a filter before sending it.
+type PropertyFilter struct {
 	Or  []PropertyFilter `json:"or,omitempty"`
 	And []PropertyFilter `json:"and,omitempty"`
-}
 
-type PropertyFilter struct {
-	Property       string             `json:"property,omitempty"`
-	Title          *TextFilter        `json:"title,omitempty"`
-	RichText       *TextFilter        `json:"rich_text,omitempty"`
-	URL            *TextFilter        `json:"url,omitempty"`
-	Email          *TextFilter        `json:"email,omitempty"`
-	Phone          *TextFilter        `json:"phone,omitempty"`
-	Number         *NumberFilter      `json:"number,omitempty"`
-	Checkbox       *CheckboxFilter    `json:"checkbox,omitempty"`
-	Select         *SelectFilter      `json:"select,omitempty"`
-	MultiSelect    *MultiSelectFilter `json:"multi_select,omitempty"`
-	Date           *DateFilter        `json:"date,omitempty"`
-	CreatedTime    *DateFilter        `json:"created_time,omitempty"`
-	LastEditedTime *DateFilter        `json:"last_edited_time,omitempty"`
-	Status         *StatusFilter      `json:"status,omitempty"`
+	Property string `json:"property,omitempty"`
+
+	// Timestamp filters on the creation or last edit time of the page instead of on a property,
+	// see FilterTimestampCreatedTime and FilterTimestampLastEditedTime.
+	Timestamp FilterTimestamp `json:"timestamp,omitempty"`
+
+	Title          *TextFilter         `json:"title,omitempty"`
+	RichText       *TextFilter         `json:"rich_text,omitempty"`
+	URL            *TextFilter         `json:"url,omitempty"`
+	Email          *TextFilter         `json:"email,omitempty"`
+	Phone          *TextFilter         `json:"phone_number,omitempty"`
+	Number         *NumberFilter       `json:"number,omitempty"`
+	Checkbox       *CheckboxFilter     `json:"checkbox,omitempty"`
+	Select         *SelectFilter       `json:"select,omitempty"`
+	MultiSelect    *MultiSelectFilter  `json:"multi_select,omitempty"`
+	Date           *DateFilter         `json:"date,omitempty"`
+	CreatedTime    *DateFilter         `json:"created_time,omitempty"`
+	LastEditedTime *DateFilter         `json:"last_edited_time,omitempty"`
+	Status         *StatusFilter       `json:"status,omitempty"`
+	Relation       *RelationFilter     `json:"relation,omitempty"`
+	People         *PeopleFilter       `json:"people,omitempty"`
+	CreatedBy      *PeopleFilter       `json:"created_by,omitempty"`
+	LastEditedBy   *PeopleFilter       `json:"last_edited_by,omitempty"`
+	Files          *FilesFilter        `json:"files,omitempty"`
+	Formula        *FormulaFilter      `json:"formula,omitempty"`
+	Rollup         *RollupFilter       `json:"rollup,omitempty"`
+	UniqueID       *UniqueIDFilter     `json:"unique_id,omitempty"`
+	Verification   *VerificationFilter `json:"verification,omitempty"`
 }
 
+type FilterTimestamp string
+
+var (
+	FilterTimestampCreatedTime    = FilterTimestamp("created_time")
+	FilterTimestampLastEditedTime = FilterTimestamp("last_edited_time")
+)
+
+// BoolFalse and BoolTrue can be used as the values of a CheckboxFilter.
+//
+// Deprecated: these are shared, mutable variables. Use Ptr(true) and Ptr(false), or the builders of
+// the filter package, instead.
 var (
 	BoolFalse = false
 	BoolTrue  = true
@@ -427,15 +937,17 @@ type TextFilter struct {
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
+// NumberFilter is used to filter pages by number property. The values are pointers so that zero
+// can be filtered on, e.g. Equals: api.Ptr(0.0).
 type NumberFilter struct {
-	Equals               int  `json:"equals,omitempty"`
-	DoesNotEqual         int  `json:"does_not_equal,omitempty"`
-	GreaterThan          int  `json:"greater_than,omitempty"`
-	LessThan             int  `json:"less_than,omitempty"`
-	GreaterThanOrEqualTo int  `json:"greater_than_or_equal_to,omitempty"`
-	LessThanOrEqualTo    int  `json:"less_than_or_equal_to,omitempty"`
-	IsEmpty              bool `json:"is_empty,omitempty"`
-	IsNotEmpty           bool `json:"is_not_empty,omitempty"`
+	Equals               *float64 `json:"equals,omitempty"`
+	DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+	GreaterThan          *float64 `json:"greater_than,omitempty"`
+	LessThan             *float64 `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
+	IsEmpty              bool     `json:"is_empty,omitempty"`
+	IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
 }
 
 type CheckboxFilter struct {
@@ -457,20 +969,24 @@ type MultiSelectFilter struct {
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
+// DateFilter is used to filter pages by date, created time or last edited time. Dates are ISO 8601
+// dates or date times, see NewDate and NewDateTime. The relative conditions, e.g. PastWeek, take
+// no value and are set with &api.EmptyConfig{}.
 type DateFilter struct {
-	Equals     string  `json:"equals,omitempty"`
-	Before     string  `json:"before,omitempty"`
-	After      string  `json:"after,omitempty"`
-	OnOrBefore string  `json:"on_or_before,omitempty"`
-	IsEmpty    bool    `json:"is_empty,omitempty"`
-	IsNotEmpty bool    `json:"is_not_empty,omitempty"`
-	OnOrAfter  *string `json:"on_or_after,omitempty"`
-	PastWeek   *string `json:"past_week,omitempty"`
-	PastMonth  *string `json:"past_month,omitempty"`
-	PastYear   *string `json:"past_year,omitempty"`
-	NextWeek   *string `json:"next_week,omitempty"`
-	NextMonth  *string `json:"next_month,omitempty"`
-	NextYear   *string `json:"next_year,omitempty"`
+	Equals     string       `json:"equals,omitempty"`
+	Before     string       `json:"before,omitempty"`
+	After      string       `json:"after,omitempty"`
+	OnOrBefore string       `json:"on_or_before,omitempty"`
+	IsEmpty    bool         `json:"is_empty,omitempty"`
+	IsNotEmpty bool         `json:"is_not_empty,omitempty"`
+	OnOrAfter  *string      `json:"on_or_after,omitempty"`
+	PastWeek   *EmptyConfig `json:"past_week,omitempty"`
+	PastMonth  *EmptyConfig `json:"past_month,omitempty"`
+	PastYear   *EmptyConfig `json:"past_year,omitempty"`
+	ThisWeek   *EmptyConfig `json:"this_week,omitempty"`
+	NextWeek   *EmptyConfig `json:"next_week,omitempty"`
+	NextMonth  *EmptyConfig `json:"next_month,omitempty"`
+	NextYear   *EmptyConfig `json:"next_year,omitempty"`
 }
 
 // StatusFilter is used to filter pages by status property.
@@ -481,13 +997,82 @@ type StatusFilter struct {
 	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
 }
 
+// RelationFilter is used to filter pages by relation property. Contains and DoesNotContain take a
+// page ID.
+type RelationFilter struct {
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// PeopleFilter is used to filter pages by people, created by and last edited by properties.
+// Contains and DoesNotContain take a user ID.
+type PeopleFilter struct {
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// FilesFilter is used to filter pages by files property.
+type FilesFilter struct {
+	IsEmpty    bool `json:"is_empty,omitempty"`
+	IsNotEmpty bool `json:"is_not_empty,omitempty"`
+}
+
+// FormulaFilter is used to filter pages by formula property. Set the condition matching the type
+// of the formula result.
+type FormulaFilter struct {
+	String   *TextFilter     `json:"string,omitempty"`
+	Checkbox *CheckboxFilter `json:"checkbox,omitempty"`
+	Number   *NumberFilter   `json:"number,omitempty"`
+	Date     *DateFilter     `json:"date,omitempty"`
+}
+
+// RollupFilter is used to filter pages by rollup property. Array rollups are filtered with Any,
+// Every or None, which hold a condition on the rolled up values without a Property, e.g.
+// &api.PropertyFilter{RichText: &api.TextFilter{Contains: "x"}}. Number and date rollups are
+// filtered with Number and Date.
+type RollupFilter struct {
+	Any    *PropertyFilter `json:"any,omitempty"`
+	Every  *PropertyFilter `json:"every,omitempty"`
+	None   *PropertyFilter `json:"none,omitempty"`
+	Number *NumberFilter   `json:"number,omitempty"`
+	Date   *DateFilter     `json:"date,omitempty"`
+}
+
+// UniqueIDFilter is used to filter pages by unique ID property. The values are the number part of
+// the ID, without the prefix.
+type UniqueIDFilter struct {
+	Equals               *int `json:"equals,omitempty"`
+	DoesNotEqual         *int `json:"does_not_equal,omitempty"`
+	GreaterThan          *int `json:"greater_than,omitempty"`
+	LessThan             *int `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *int `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *int `json:"less_than_or_equal_to,omitempty"`
+}
+
+// VerificationFilter is used to filter wiki pages by verification status.
+type VerificationFilter struct {
+	Status VerificationStatus `json:"status,omitempty"`
+}
+
+type VerificationStatus string
+
+var (
+	VerificationStatusVerified = VerificationStatus("verified")
+	VerificationStatusExpired  = VerificationStatus("expired")
+	VerificationStatusNone     = VerificationStatus("none")
+)
+
 // DataSource represents a data source within a multi-source database.
 // Added in API version 2025-09-03.
 type DataSource struct {
 	CommonObject `json:",inline"`
 
 	// Title of data source as it appears in Notion.
-	Title []Title `json:"title,omitempty"`
+	Title []RichText `json:"title,omitempty"`
 
 	// Property schema of this data source.
 	Properties map[string]Property `json:"properties,omitempty"`
@@ -496,12 +1081,50 @@ type DataSource struct {
 	Parent Parent `json:"parent,omitempty"`
 }
 
+// UpdateDataSourceRequest is used to change the property schema of a data source.
+type UpdateDataSourceRequest struct {
+	// Properties to add or change, keyed by name or ID. Select and multi select options that are
+	// left out of a changed property are removed from it.
+	Properties map[string]Property `json:"properties,omitempty"`
+}
+
 // DataSourceResponseList is used to parse the response when listing data sources.
 type DataSourceResponseList struct {
 	Response `json:",inline"`
 	Results  []DataSource `json:"results,omitempty"`
 }
 
+// Template is a page template of a data source.
+type Template struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Whether the template is applied to pages created in the Notion app by default.
+	IsDefault bool `json:"is_default,omitempty"`
+}
+
+// TemplateResponseList is used to parse the response when listing the templates of a data source.
+type TemplateResponseList struct {
+	Response  `json:",inline"`
+	Templates []Template `json:"templates,omitempty"`
+}
+
+// PageTemplate selects the template applied to a page created in a data source.
+type PageTemplate struct {
+	Type PageTemplateType `json:"type"`
+
+	// ID of the template, when Type is "template_id". See Template.
+	TemplateID string `json:"template_id,omitempty"`
+}
+
+type PageTemplateType string
+
+var (
+	PageTemplateTypeNone       = PageTemplateType("none")
+	PageTemplateTypeDefault    = PageTemplateType("default")
+	PageTemplateTypeTemplateID = PageTemplateType("template_id")
+)
+
 // MovePageRequest is used to move a page to a new parent.
 type MovePageRequest struct {
 	// The new parent for the page.
@@ -520,3 +1143,133 @@ type PagePosition struct {
 	// The sibling page ID to position relative to.
 	PageID string `json:"page_id,omitempty"`
 }
+
+// UpdatePageRequest is used to update an existing page. Only the properties present in Properties
+// are changed, the rest are left untouched.
+type UpdatePageRequest struct {
+	// Property values to update. A value with a nil or empty payload clears the property, see
+	// ValueProperty.
+	Properties map[string]ValueProperty `json:"properties,omitempty"`
+
+	// Set to true to move the page to the trash, or false to restore it.
+	InTrash *bool `json:"in_trash,omitempty"`
+
+	// Whether the page should be locked from editing in the Notion app UI.
+	IsLocked *bool `json:"is_locked,omitempty"`
+
+	// New page icon and cover.
+	Icon  *Icon `json:"icon,omitempty"`
+	Cover *File `json:"cover,omitempty"`
+}
+
+// Comment is a comment on a page or a block. Comments that reply to each other share the same
+// discussion id.
+type Comment struct {
+	CommonObject `json:",inline"`
+
+	// The page or block the comment is attached to.
+	Parent Parent `json:"parent,omitempty"`
+
+	// ID of the discussion thread the comment belongs to.
+	DiscussionID string `json:"discussion_id,omitempty"`
+
+	// Content of the comment.
+	RichText []RichText `json:"rich_text,omitempty"`
+}
+
+// CommentResponseList is used to parse the response when listing comments.
+type CommentResponseList struct {
+	Response `json:",inline"`
+	Results  []Comment `json:"results,omitempty"`
+}
+
+// CreateCommentRequest is used to create a comment. Either Parent is set to start a new discussion
+// on a page or block, or DiscussionID is set to reply to an existing discussion.
+type CreateCommentRequest struct {
+	Parent       *Parent    `json:"parent,omitempty"`
+	DiscussionID string     `json:"discussion_id,omitempty"`
+	RichText     []RichText `json:"rich_text"`
+}
+
+// FileUpload is a file being uploaded to Notion using the File Upload API. Once its status is
+// "uploaded" it can be referenced with a FileUploadRef.
+type FileUpload struct {
+	CommonObject `json:",inline"`
+
+	// Date and time when the upload expires if it is not attached to anything.
+	ExpiryTime string `json:"expiry_time,omitempty"`
+
+	// Status of the upload. One of "pending", "uploaded", "expired" or "failed".
+	Status FileUploadStatus `json:"status,omitempty"`
+
+	Filename      string `json:"filename,omitempty"`
+	ContentType   string `json:"content_type,omitempty"`
+	ContentLength int64  `json:"content_length,omitempty"`
+
+	// URLs used to send the file contents and to complete a multi-part upload.
+	UploadURL   string `json:"upload_url,omitempty"`
+	CompleteURL string `json:"complete_url,omitempty"`
+
+	// Progress of a multi-part upload.
+	NumberOfParts *FileUploadParts `json:"number_of_parts,omitempty"`
+
+	// Result of an import from an external URL.
+	FileImportResult *FileImportResult `json:"file_import_result,omitempty"`
+}
+
+type FileUploadStatus string
+
+var (
+	FileUploadStatusPending  = FileUploadStatus("pending")
+	FileUploadStatusUploaded = FileUploadStatus("uploaded")
+	FileUploadStatusExpired  = FileUploadStatus("expired")
+	FileUploadStatusFailed   = FileUploadStatus("failed")
+)
+
+// FileUploadParts tracks how many parts of a multi-part upload have been sent.
+type FileUploadParts struct {
+	Total     int `json:"total,omitempty"`
+	SentCount int `json:"sent_count,omitempty"`
+}
+
+// FileImportResult is the outcome of importing a file from an external URL.
+type FileImportResult struct {
+	ImportedTime string `json:"imported_time,omitempty"`
+
+	// Either "success" or "error".
+	Type string `json:"type,omitempty"`
+
+	Error *FileImportError `json:"error,omitempty"`
+}
+
+// FileImportError describes why importing a file from an external URL failed.
+type FileImportError struct {
+	Type       string `json:"type,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Parameter  string `json:"parameter,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+// CreateFileUploadRequest is used to start a file upload.
+type CreateFileUploadRequest struct {
+	// How the file is sent. Defaults to "single_part".
+	Mode FileUploadMode `json:"mode,omitempty"`
+
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+
+	// Number of parts the file is split into. Only used with the "multi_part" mode.
+	NumberOfParts int `json:"number_of_parts,omitempty"`
+
+	// URL to import the file from. Only used with the "external_url" mode.
+	ExternalURL string `json:"external_url,omitempty"`
+}
+
+type FileUploadMode string
+
+var (
+	FileUploadModeSinglePart  = FileUploadMode("single_part")
+	FileUploadModeMultiPart   = FileUploadMode("multi_part")
+	FileUploadModeExternalURL = FileUploadMode("external_url")
+)
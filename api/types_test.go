@@ -84,8 +84,8 @@ func TestDatabase_JSON(t *testing.T) {
 			CreatedTime:    "2020-03-17T19:10:04.968Z",
 			LastEditedTime: "2020-03-17T21:49:37.913Z",
 		},
-		Title: []Title{
-			{Text: Text{Content: "My DB"}, PlainText: "My DB", Type: "text"},
+		Title: []RichText{
+			{Text: &Text{Content: "My DB"}, PlainText: "My DB", Type: "text"},
 		},
 		Properties: map[string]Property{
 			"Name": {ID: "title", Type: "title"},
@@ -140,7 +140,7 @@ func TestPage_JSON(t *testing.T) {
 		Properties: map[string]ValueProperty{
 			"Name": {
 				Type:  ValuePropertyTypeTitle,
-				Title: []Title{{PlainText: "My Page"}},
+				Title: []RichText{{PlainText: "My Page"}},
 			},
 		},
 	}
@@ -304,10 +304,10 @@ func TestUserResponseList_JSON(t *testing.T) {
 func TestTitle_JSON(t *testing.T) {
 	title := Title{
 		Type:      "text",
-		Text:      Text{Content: "Hello", Link: &Link{URL: "https://example.com"}},
+		Text:      &Text{Content: "Hello", Link: &Link{URL: "https://example.com"}},
 		PlainText: "Hello",
 		Href:      "https://example.com",
-		Annotations: Annotation{
+		Annotations: &Annotation{
 			Bold:   true,
 			Italic: true,
 			Color:  ColorBlue,
@@ -370,7 +370,7 @@ func TestProperty_Select(t *testing.T) {
 	prop := Property{
 		ID:   "prop-1",
 		Type: "select",
-		Select: Select{
+		Select: &Select{
 			Options: []Option{
 				{ID: "opt-1", Name: "Option A", Color: "blue"},
 				{ID: "opt-2", Name: "Option B", Color: "red"},
@@ -396,7 +396,7 @@ func TestProperty_MultiSelect(t *testing.T) {
 	prop := Property{
 		ID:   "prop-2",
 		Type: "multi_select",
-		MultiSelect: Select{
+		MultiSelect: &Select{
 			Options: []Option{
 				{ID: "opt-1", Name: "Tag A"},
 			},
@@ -412,7 +412,7 @@ func TestProperty_Number(t *testing.T) {
 	prop := Property{
 		ID:     "prop-3",
 		Type:   "number",
-		Number: Number{Format: "percent"},
+		Number: &Number{Format: "percent"},
 	}
 	got := jsonRoundTrip(t, prop)
 	if got.Number.Format != "percent" {
@@ -424,7 +424,7 @@ func TestProperty_Status(t *testing.T) {
 	prop := Property{
 		ID:   "prop-4",
 		Type: "status",
-		Status: Status{
+		Status: &Status{
 			Options: []StatusOption{
 				{ID: "so-1", Name: "Not started", Color: "default"},
 				{ID: "so-2", Name: "In progress", Color: "blue"},
@@ -452,6 +452,119 @@ func TestProperty_Status(t *testing.T) {
 	}
 }
 
+func TestProperty_Formula(t *testing.T) {
+	prop := Property{
+		ID:      "prop-5",
+		Name:    "Total",
+		Type:    "formula",
+		Formula: &FormulaConfig{Expression: "prop(\"Price\") * prop(\"Qty\")"},
+	}
+	got := jsonRoundTrip(t, prop)
+	if got.Name != "Total" {
+		t.Errorf("expected Name %q, got %q", "Total", got.Name)
+	}
+	if got.Formula == nil || got.Formula.Expression != prop.Formula.Expression {
+		t.Errorf("unexpected Formula: %+v", got.Formula)
+	}
+}
+
+func TestProperty_Relation(t *testing.T) {
+	prop := Property{
+		ID:   "prop-6",
+		Type: "relation",
+		Relation: &RelationConfig{
+			DataSourceID: "ds-1",
+			Type:         RelationTypeDualProperty,
+			DualProperty: &DualProperty{SyncedPropertyName: "Tasks", SyncedPropertyID: "abc"},
+		},
+	}
+	got := jsonRoundTrip(t, prop)
+	if got.Relation == nil {
+		t.Fatal("expected Relation")
+	}
+	if got.Relation.DataSourceID != "ds-1" {
+		t.Errorf("expected DataSourceID %q, got %q", "ds-1", got.Relation.DataSourceID)
+	}
+	if got.Relation.Type != RelationTypeDualProperty {
+		t.Errorf("expected Type %q, got %q", RelationTypeDualProperty, got.Relation.Type)
+	}
+	if got.Relation.DualProperty == nil || got.Relation.DualProperty.SyncedPropertyName != "Tasks" {
+		t.Errorf("unexpected DualProperty: %+v", got.Relation.DualProperty)
+	}
+	if got.Relation.SingleProperty != nil {
+		t.Error("expected SingleProperty nil")
+	}
+}
+
+func TestProperty_Rollup(t *testing.T) {
+	prop := Property{
+		ID:   "prop-7",
+		Type: "rollup",
+		Rollup: &RollupConfig{
+			RelationPropertyName: "Tasks",
+			RelationPropertyID:   "rel-1",
+			RollupPropertyName:   "Estimate",
+			RollupPropertyID:     "est-1",
+			Function:             "sum",
+		},
+	}
+	got := jsonRoundTrip(t, prop)
+	if got.Rollup == nil || *got.Rollup != *prop.Rollup {
+		t.Errorf("unexpected Rollup: %+v", got.Rollup)
+	}
+}
+
+func TestProperty_UniqueID(t *testing.T) {
+	prefix := "TASK"
+	prop := Property{ID: "prop-8", Type: "unique_id", UniqueID: &UniqueIDConfig{Prefix: &prefix}}
+	got := jsonRoundTrip(t, prop)
+	if got.UniqueID == nil || got.UniqueID.Prefix == nil || *got.UniqueID.Prefix != "TASK" {
+		t.Errorf("unexpected UniqueID: %+v", got.UniqueID)
+	}
+
+	data, err := json.Marshal(Property{Type: "unique_id", UniqueID: &UniqueIDConfig{}})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if want := `{"type":"unique_id","unique_id":{"prefix":null}}`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestProperty_RoundTripAllTypes(t *testing.T) {
+	tests := []string{
+		`{"id":"title","name":"Name","type":"title","title":{}}`,
+		`{"id":"a","name":"Notes","type":"rich_text","rich_text":{}}`,
+		`{"id":"b","name":"Owner","type":"people","people":{}}`,
+		`{"id":"c","name":"Attachments","type":"files","files":{}}`,
+		`{"id":"d","name":"Link","type":"url","url":{}}`,
+		`{"id":"e","name":"Mail","type":"email","email":{}}`,
+		`{"id":"f","name":"Phone","type":"phone_number","phone_number":{}}`,
+		`{"id":"g","name":"Created","type":"created_time","created_time":{}}`,
+		`{"id":"h","name":"Creator","type":"created_by","created_by":{}}`,
+		`{"id":"i","name":"Edited","type":"last_edited_time","last_edited_time":{}}`,
+		`{"id":"j","name":"Editor","type":"last_edited_by","last_edited_by":{}}`,
+		`{"id":"k","name":"Verified","type":"verification","verification":{}}`,
+		`{"id":"l","name":"Run","type":"button","button":{}}`,
+		`{"id":"m","name":"Done","type":"checkbox","checkbox":{}}`,
+		`{"id":"n","name":"Due","type":"date","date":{}}`,
+		`{"id":"o","name":"Related","type":"relation","relation":{"data_source_id":"ds-1","type":"single_property","single_property":{}}}`,
+	}
+	for _, want := range tests {
+		var prop Property
+		if err := json.Unmarshal([]byte(want), &prop); err != nil {
+			t.Fatalf("json.Unmarshal failed: %v", err)
+		}
+		got, err := json.Marshal(prop)
+		if err != nil {
+			t.Fatalf("json.Marshal failed: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("round trip mismatch:\nwant %s\ngot  %s", want, got)
+		}
+	}
+}
+
 func TestParent_Database(t *testing.T) {
 	parent := Parent{Type: ParentTypeDatabase, DatabaseID: "db-123"}
 	got := jsonRoundTrip(t, parent)
@@ -489,7 +602,7 @@ func TestValueProperty_Title(t *testing.T) {
 	vp := ValueProperty{
 		ID:    "vp-1",
 		Type:  ValuePropertyTypeTitle,
-		Title: []Title{{PlainText: "Test Title"}},
+		Title: []RichText{{PlainText: "Test Title"}},
 	}
 	got := jsonRoundTrip(t, vp)
 	if got.Type != ValuePropertyTypeTitle {
@@ -503,7 +616,7 @@ func TestValueProperty_Title(t *testing.T) {
 func TestValueProperty_RichText(t *testing.T) {
 	vp := ValueProperty{
 		Type:     ValuePropertyTypeRichText,
-		RichText: []Title{{PlainText: "Some text"}},
+		RichText: []RichText{{PlainText: "Some text"}},
 	}
 	got := jsonRoundTrip(t, vp)
 	if got.Type != ValuePropertyTypeRichText {
@@ -517,11 +630,63 @@ func TestValueProperty_RichText(t *testing.T) {
 func TestValueProperty_Number(t *testing.T) {
 	vp := ValueProperty{
 		Type:   ValuePropertyTypeNumber,
-		Number: 42.5,
+		Number: Ptr(42.5),
 	}
 	got := jsonRoundTrip(t, vp)
-	if got.Number != 42.5 {
-		t.Errorf("expected Number 42.5, got %f", got.Number)
+	if got.Number == nil || *got.Number != 42.5 {
+		t.Errorf("expected Number 42.5, got %v", got.Number)
+	}
+}
+
+func TestValueProperty_MarshalZeroAndNull(t *testing.T) {
+	tests := []struct {
+		name string
+		vp   ValueProperty
+		want string
+	}{
+		{"zero number", ValueProperty{Type: ValuePropertyTypeNumber, Number: Ptr(0.0)}, `{"number":0,"type":"number"}`},
+		{"clear number", ValueProperty{Type: ValuePropertyTypeNumber}, `{"number":null,"type":"number"}`},
+		{"unchecked", ValueProperty{Type: ValuePropertyTypeCheckbox}, `{"checkbox":false,"type":"checkbox"}`},
+		{"clear date", ValueProperty{Type: ValuePropertyTypeDate}, `{"date":null,"type":"date"}`},
+		{"clear select", ValueProperty{Type: ValuePropertyTypeSelect}, `{"select":null,"type":"select"}`},
+		{"clear status", ValueProperty{Type: ValuePropertyTypeStatus}, `{"status":null,"type":"status"}`},
+		{"clear url", ValueProperty{Type: ValuePropertyTypeURL}, `{"type":"url","url":null}`},
+		{"clear email", ValueProperty{Type: ValuePropertyTypeEmail}, `{"email":null,"type":"email"}`},
+		{"clear phone", ValueProperty{Type: ValuePropertyTypePhoneNumber}, `{"phone_number":null,"type":"phone_number"}`},
+		{"clear multi_select", ValueProperty{Type: ValuePropertyTypeMultiSelect}, `{"multi_select":[],"type":"multi_select"}`},
+		{"clear relation", ValueProperty{Type: ValuePropertyTypeRelation}, `{"relation":[],"type":"relation"}`},
+		{"clear people", ValueProperty{Type: ValuePropertyTypePeople}, `{"people":[],"type":"people"}`},
+		{"clear files", ValueProperty{Type: ValuePropertyTypeFiles}, `{"files":[],"type":"files"}`},
+		{"clear rich_text", ValueProperty{Type: ValuePropertyTypeRichText}, `{"rich_text":[],"type":"rich_text"}`},
+		{"set url", ValueProperty{Type: ValuePropertyTypeURL, URL: "https://example.com"}, `{"type":"url","url":"https://example.com"}`},
+		{"read-only formula", ValueProperty{ID: "f", Type: ValuePropertyTypeFormula}, `{"id":"f","type":"formula"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.vp)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, data)
+			}
+		})
+	}
+}
+
+func TestValueProperty_UnmarshalNullNumber(t *testing.T) {
+	var vp ValueProperty
+	if err := json.Unmarshal([]byte(`{"type":"number","number":null}`), &vp); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if vp.Number != nil {
+		t.Errorf("expected nil Number, got %v", *vp.Number)
+	}
+	if err := json.Unmarshal([]byte(`{"type":"number","number":0}`), &vp); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if vp.Number == nil || *vp.Number != 0 {
+		t.Errorf("expected Number 0, got %v", vp.Number)
 	}
 }
 
@@ -614,6 +779,204 @@ func TestValueProperty_Status(t *testing.T) {
 	}
 }
 
+func TestValueProperty_People(t *testing.T) {
+	vp := ValueProperty{
+		Type: ValuePropertyTypePeople,
+		People: []User{
+			{Object: "user", ID: "user-1", Type: "person", Name: "Ada", Person: &Person{Email: "ada@example.com"}},
+		},
+	}
+	got := jsonRoundTrip(t, vp)
+	if len(got.People) != 1 || got.People[0].Person == nil || got.People[0].Person.Email != "ada@example.com" {
+		t.Errorf("unexpected People: %+v", got.People)
+	}
+}
+
+func TestValueProperty_Files(t *testing.T) {
+	vp := ValueProperty{
+		Type: ValuePropertyTypeFiles,
+		Files: []File{
+			{Name: "report.pdf", Type: "file", File: &NotionFile{URL: "https://s3/report.pdf", ExpiryTime: "2024-01-01T00:00:00.000Z"}},
+			{Name: "logo", Type: "external", External: &External{URL: "https://example.com/logo.png"}},
+		},
+	}
+	got := jsonRoundTrip(t, vp)
+	if len(got.Files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(got.Files))
+	}
+	if got.Files[0].File == nil || got.Files[0].File.ExpiryTime != "2024-01-01T00:00:00.000Z" {
+		t.Errorf("unexpected hosted file: %+v", got.Files[0])
+	}
+	if got.Files[1].External == nil || got.Files[1].External.URL != "https://example.com/logo.png" {
+		t.Errorf("unexpected external file: %+v", got.Files[1])
+	}
+}
+
+func TestValueProperty_EmailAndPhone(t *testing.T) {
+	got := jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeEmail, Email: "ada@example.com"})
+	if got.Email != "ada@example.com" {
+		t.Errorf("expected Email %q, got %q", "ada@example.com", got.Email)
+	}
+	got = jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypePhoneNumber, PhoneNumber: "+1 555 0100"})
+	if got.PhoneNumber != "+1 555 0100" {
+		t.Errorf("expected PhoneNumber %q, got %q", "+1 555 0100", got.PhoneNumber)
+	}
+}
+
+func TestValueProperty_Formula(t *testing.T) {
+	tests := map[string]string{
+		"string":  `{"type":"formula","formula":{"type":"string","string":"hello"}}`,
+		"number":  `{"type":"formula","formula":{"type":"number","number":2.5}}`,
+		"boolean": `{"type":"formula","formula":{"type":"boolean","boolean":false}}`,
+		"date":    `{"type":"formula","formula":{"type":"date","date":{"start":"2023-01-01"}}}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var vp ValueProperty
+			if err := json.Unmarshal([]byte(data), &vp); err != nil {
+				t.Fatalf("json.Unmarshal failed: %v", err)
+			}
+			got := jsonRoundTrip(t, vp)
+			if got.Formula == nil || got.Formula.Type != name {
+				t.Fatalf("unexpected Formula: %+v", got.Formula)
+			}
+			switch name {
+			case "string":
+				if got.Formula.String == nil || *got.Formula.String != "hello" {
+					t.Errorf("unexpected String: %v", got.Formula.String)
+				}
+			case "number":
+				if got.Formula.Number == nil || *got.Formula.Number != 2.5 {
+					t.Errorf("unexpected Number: %v", got.Formula.Number)
+				}
+			case "boolean":
+				if got.Formula.Boolean == nil || *got.Formula.Boolean {
+					t.Errorf("unexpected Boolean: %v", got.Formula.Boolean)
+				}
+			case "date":
+				if got.Formula.Date == nil || got.Formula.Date.Start != "2023-01-01" {
+					t.Errorf("unexpected Date: %+v", got.Formula.Date)
+				}
+			}
+		})
+	}
+}
+
+func TestValueProperty_Rollup(t *testing.T) {
+	number := 42.0
+	got := jsonRoundTrip(t, ValueProperty{
+		Type:   ValuePropertyTypeRollup,
+		Rollup: &RollupValue{Type: "number", Function: "sum", Number: &number},
+	})
+	if got.Rollup == nil || got.Rollup.Number == nil || *got.Rollup.Number != 42 {
+		t.Errorf("unexpected number Rollup: %+v", got.Rollup)
+	}
+
+	got = jsonRoundTrip(t, ValueProperty{
+		Type:   ValuePropertyTypeRollup,
+		Rollup: &RollupValue{Type: "date", Function: "latest_date", Date: &DateRange{Start: "2023-05-01"}},
+	})
+	if got.Rollup == nil || got.Rollup.Date == nil || got.Rollup.Date.Start != "2023-05-01" {
+		t.Errorf("unexpected date Rollup: %+v", got.Rollup)
+	}
+
+	got = jsonRoundTrip(t, ValueProperty{
+		Type: ValuePropertyTypeRollup,
+		Rollup: &RollupValue{
+			Type:     "array",
+			Function: "show_original",
+			Array: []ValueProperty{
+				{Type: ValuePropertyTypeTitle, Title: []RichText{{PlainText: "Task A"}}},
+				{Type: ValuePropertyTypeTitle, Title: []RichText{{PlainText: "Task B"}}},
+			},
+		},
+	})
+	if got.Rollup == nil || len(got.Rollup.Array) != 2 {
+		t.Fatalf("unexpected array Rollup: %+v", got.Rollup)
+	}
+	if got.Rollup.Array[1].Title[0].PlainText != "Task B" {
+		t.Errorf("unexpected array element: %+v", got.Rollup.Array[1])
+	}
+}
+
+func TestValueProperty_CreatedAndEdited(t *testing.T) {
+	user := &User{Object: "user", ID: "user-1"}
+	got := jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeCreatedBy, CreatedBy: user})
+	if got.CreatedBy == nil || got.CreatedBy.ID != "user-1" {
+		t.Errorf("unexpected CreatedBy: %+v", got.CreatedBy)
+	}
+	got = jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeLastEditedBy, LastEditedBy: user})
+	if got.LastEditedBy == nil || got.LastEditedBy.ID != "user-1" {
+		t.Errorf("unexpected LastEditedBy: %+v", got.LastEditedBy)
+	}
+	got = jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeCreatedTime, CreatedTime: "2023-01-01T00:00:00.000Z"})
+	if got.CreatedTime != "2023-01-01T00:00:00.000Z" {
+		t.Errorf("unexpected CreatedTime: %q", got.CreatedTime)
+	}
+	got = jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeLastEditedTime, LastEditedTime: "2023-02-01T00:00:00.000Z"})
+	if got.LastEditedTime != "2023-02-01T00:00:00.000Z" {
+		t.Errorf("unexpected LastEditedTime: %q", got.LastEditedTime)
+	}
+}
+
+func TestValueProperty_UniqueID(t *testing.T) {
+	prefix := "TASK"
+	got := jsonRoundTrip(t, ValueProperty{
+		Type:     ValuePropertyTypeUniqueID,
+		UniqueID: &UniqueIDValue{Prefix: &prefix, Number: 42},
+	})
+	if got.UniqueID == nil || got.UniqueID.Prefix == nil || *got.UniqueID.Prefix != "TASK" || got.UniqueID.Number != 42 {
+		t.Errorf("unexpected UniqueID: %+v", got.UniqueID)
+	}
+
+	got = jsonRoundTrip(t, ValueProperty{Type: ValuePropertyTypeUniqueID, UniqueID: &UniqueIDValue{Number: 7}})
+	if got.UniqueID == nil || got.UniqueID.Prefix != nil || got.UniqueID.Number != 7 {
+		t.Errorf("unexpected UniqueID without prefix: %+v", got.UniqueID)
+	}
+}
+
+func TestValueProperty_Verification(t *testing.T) {
+	got := jsonRoundTrip(t, ValueProperty{
+		Type: ValuePropertyTypeVerification,
+		Verification: &VerificationValue{
+			State:      "verified",
+			VerifiedBy: &User{ID: "user-1"},
+			Date:       &DateRange{Start: "2023-08-01T00:00:00.000Z"},
+		},
+	})
+	if got.Verification == nil || got.Verification.State != "verified" {
+		t.Fatalf("unexpected Verification: %+v", got.Verification)
+	}
+	if got.Verification.VerifiedBy == nil || got.Verification.VerifiedBy.ID != "user-1" {
+		t.Errorf("unexpected VerifiedBy: %+v", got.Verification.VerifiedBy)
+	}
+	if got.Verification.Date == nil || got.Verification.Date.Start != "2023-08-01T00:00:00.000Z" {
+		t.Errorf("unexpected Date: %+v", got.Verification.Date)
+	}
+}
+
+func TestValueProperty_Button(t *testing.T) {
+	var vp ValueProperty
+	if err := json.Unmarshal([]byte(`{"id":"x","type":"button","button":{}}`), &vp); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	got := jsonRoundTrip(t, vp)
+	if got.Button == nil {
+		t.Error("expected Button to be set")
+	}
+}
+
+func TestValueProperty_HasMore(t *testing.T) {
+	got := jsonRoundTrip(t, ValueProperty{
+		Type:     ValuePropertyTypeRelation,
+		Relation: []Relation{{ID: "page-1"}},
+		HasMore:  true,
+	})
+	if !got.HasMore {
+		t.Error("expected HasMore true")
+	}
+}
+
 func TestSort_JSON(t *testing.T) {
 	sort := Sort{
 		Property:  "Name",
@@ -703,7 +1066,7 @@ func TestPropertyFilter_AllTypes(t *testing.T) {
 		URL:            &TextFilter{Equals: "https://example.com"},
 		Email:          &TextFilter{EndsWith: "@test.com"},
 		Phone:          &TextFilter{DoesNotContain: "555"},
-		Number:         &NumberFilter{GreaterThan: 10},
+		Number:         &NumberFilter{GreaterThan: Ptr(10.0)},
 		Checkbox:       &CheckboxFilter{Equals: &BoolTrue},
 		Select:         &SelectFilter{DoesNotEqual: "bad"},
 		MultiSelect:    &MultiSelectFilter{Contains: "tag"},
@@ -728,7 +1091,7 @@ func TestPropertyFilter_AllTypes(t *testing.T) {
 	if got.Phone == nil || got.Phone.DoesNotContain != "555" {
 		t.Errorf("unexpected Phone filter: %+v", got.Phone)
 	}
-	if got.Number == nil || got.Number.GreaterThan != 10 {
+	if got.Number == nil || got.Number.GreaterThan == nil || *got.Number.GreaterThan != 10 {
 		t.Errorf("unexpected Number filter: %+v", got.Number)
 	}
 	if got.Checkbox == nil || got.Checkbox.Equals == nil || *got.Checkbox.Equals != true {
@@ -810,33 +1173,30 @@ func TestTextFilter_JSON(t *testing.T) {
 
 func TestNumberFilter_JSON(t *testing.T) {
 	nf := NumberFilter{
-		Equals:               5,
-		DoesNotEqual:         10,
-		GreaterThan:          3,
-		LessThan:             20,
-		GreaterThanOrEqualTo: 5,
-		LessThanOrEqualTo:    20,
+		Equals:               Ptr(5.0),
+		DoesNotEqual:         Ptr(10.0),
+		GreaterThan:          Ptr(2.5),
+		LessThan:             Ptr(20.0),
+		GreaterThanOrEqualTo: Ptr(0.0),
+		LessThanOrEqualTo:    Ptr(20.0),
 		IsEmpty:              true,
 		IsNotEmpty:           true,
 	}
 	got := jsonRoundTrip(t, nf)
-	if got.Equals != 5 {
-		t.Errorf("expected Equals 5, got %d", got.Equals)
-	}
-	if got.DoesNotEqual != 10 {
-		t.Errorf("expected DoesNotEqual 10, got %d", got.DoesNotEqual)
-	}
-	if got.GreaterThan != 3 {
-		t.Errorf("expected GreaterThan 3, got %d", got.GreaterThan)
-	}
-	if got.LessThan != 20 {
-		t.Errorf("expected LessThan 20, got %d", got.LessThan)
-	}
-	if got.GreaterThanOrEqualTo != 5 {
-		t.Errorf("expected GreaterThanOrEqualTo 5, got %d", got.GreaterThanOrEqualTo)
-	}
-	if got.LessThanOrEqualTo != 20 {
-		t.Errorf("expected LessThanOrEqualTo 20, got %d", got.LessThanOrEqualTo)
+	for name, tt := range map[string]struct {
+		got  *float64
+		want float64
+	}{
+		"Equals":               {got.Equals, 5},
+		"DoesNotEqual":         {got.DoesNotEqual, 10},
+		"GreaterThan":          {got.GreaterThan, 2.5},
+		"LessThan":             {got.LessThan, 20},
+		"GreaterThanOrEqualTo": {got.GreaterThanOrEqualTo, 0},
+		"LessThanOrEqualTo":    {got.LessThanOrEqualTo, 20},
+	} {
+		if tt.got == nil || *tt.got != tt.want {
+			t.Errorf("expected %s %v, got %v", name, tt.want, tt.got)
+		}
 	}
 	if !got.IsEmpty {
 		t.Error("expected IsEmpty true")
@@ -844,6 +1204,14 @@ func TestNumberFilter_JSON(t *testing.T) {
 	if !got.IsNotEmpty {
 		t.Error("expected IsNotEmpty true")
 	}
+
+	b, err := json.Marshal(NumberFilter{Equals: Ptr(0.0)})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(b) != `{"equals":0}` {
+		t.Errorf("expected %s, got %s", `{"equals":0}`, b)
+	}
 }
 
 func TestSelectFilter_JSON(t *testing.T) {
@@ -886,12 +1254,6 @@ func TestMultiSelectFilter_JSON(t *testing.T) {
 
 func TestDateFilter_JSON(t *testing.T) {
 	onOrAfter := "2021-01-01"
-	pastWeek := ""
-	pastMonth := ""
-	pastYear := ""
-	nextWeek := ""
-	nextMonth := ""
-	nextYear := ""
 	df := DateFilter{
 		Equals:     "2020-01-01",
 		Before:     "2020-06-01",
@@ -900,12 +1262,12 @@ func TestDateFilter_JSON(t *testing.T) {
 		IsEmpty:    true,
 		IsNotEmpty: true,
 		OnOrAfter:  &onOrAfter,
-		PastWeek:   &pastWeek,
-		PastMonth:  &pastMonth,
-		PastYear:   &pastYear,
-		NextWeek:   &nextWeek,
-		NextMonth:  &nextMonth,
-		NextYear:   &nextYear,
+		PastWeek:   &EmptyConfig{},
+		PastMonth:  &EmptyConfig{},
+		PastYear:   &EmptyConfig{},
+		NextWeek:   &EmptyConfig{},
+		NextMonth:  &EmptyConfig{},
+		NextYear:   &EmptyConfig{},
 	}
 	got := jsonRoundTrip(t, df)
 	if got.Equals != "2020-01-01" {
@@ -974,7 +1336,7 @@ func TestStatusFilter_JSON(t *testing.T) {
 func TestDataSource_JSON(t *testing.T) {
 	ds := DataSource{
 		CommonObject: CommonObject{ID: "ds-123", Object: "data_source"},
-		Title:        []Title{{PlainText: "Data Source 1"}},
+		Title:        []RichText{{PlainText: "Data Source 1"}},
 		Properties:   map[string]Property{"Col": {ID: "c1", Type: "title"}},
 		Parent:       Parent{Type: ParentTypeDatabase, DatabaseID: "db-123"},
 	}
@@ -1032,6 +1394,160 @@ func TestMovePageRequest_JSON(t *testing.T) {
 	}
 }
 
+func TestComment_JSON(t *testing.T) {
+	comment := Comment{
+		CommonObject: CommonObject{ID: "c-1", Object: "comment", CreatedBy: &User{ID: "user-1"}},
+		Parent:       Parent{Type: ParentTypeBlock, BlockID: "block-1"},
+		DiscussionID: "d-1",
+		RichText:     []RichText{{Type: "text", Text: &Text{Content: "Looks good"}, PlainText: "Looks good"}},
+	}
+	got := jsonRoundTrip(t, comment)
+	if got.Parent.Type != ParentTypeBlock || got.Parent.BlockID != "block-1" {
+		t.Errorf("unexpected Parent: %+v", got.Parent)
+	}
+	if got.DiscussionID != "d-1" {
+		t.Errorf("expected DiscussionID %q, got %q", "d-1", got.DiscussionID)
+	}
+	if len(got.RichText) != 1 || got.RichText[0].PlainText != "Looks good" {
+		t.Errorf("unexpected RichText: %+v", got.RichText)
+	}
+	if got.CreatedBy == nil || got.CreatedBy.ID != "user-1" {
+		t.Errorf("unexpected CreatedBy: %+v", got.CreatedBy)
+	}
+}
+
+func TestCreateCommentRequest_JSON(t *testing.T) {
+	data, err := json.Marshal(CreateCommentRequest{DiscussionID: "d-1", RichText: []RichText{}})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if want := `{"discussion_id":"d-1","rich_text":[]}`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestPage_IconAndCover(t *testing.T) {
+	pg := Page{
+		Icon:  &Icon{Type: "file_upload", FileUpload: &FileUploadRef{ID: "fu-1"}},
+		Cover: &File{Type: "external", External: &External{URL: "https://example.com/cover.png"}},
+	}
+	got := jsonRoundTrip(t, pg)
+	if got.Icon == nil || got.Icon.FileUpload == nil || got.Icon.FileUpload.ID != "fu-1" {
+		t.Errorf("unexpected Icon: %+v", got.Icon)
+	}
+	if got.Cover == nil || got.Cover.External == nil || got.Cover.External.URL != "https://example.com/cover.png" {
+		t.Errorf("unexpected Cover: %+v", got.Cover)
+	}
+
+	got = jsonRoundTrip(t, Page{Icon: &Icon{Type: "emoji", Emoji: "🚀"}})
+	if got.Icon == nil || got.Icon.Emoji != "🚀" {
+		t.Errorf("unexpected emoji Icon: %+v", got.Icon)
+	}
+}
+
+func TestFile_FileUpload(t *testing.T) {
+	data, err := json.Marshal(File{Name: "report.pdf", Type: "file_upload", FileUpload: &FileUploadRef{ID: "fu-1"}})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if want := `{"name":"report.pdf","type":"file_upload","file_upload":{"id":"fu-1"}}`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestFileUpload_JSON(t *testing.T) {
+	fu := FileUpload{
+		CommonObject:  CommonObject{ID: "fu-1", Object: "file_upload"},
+		ExpiryTime:    "2025-01-01T01:00:00.000Z",
+		Status:        FileUploadStatusPending,
+		Filename:      "big.bin",
+		ContentType:   "application/octet-stream",
+		ContentLength: 42,
+		UploadURL:     "https://api.notion.com/v1/file_uploads/fu-1/send",
+		CompleteURL:   "https://api.notion.com/v1/file_uploads/fu-1/complete",
+		NumberOfParts: &FileUploadParts{Total: 3, SentCount: 1},
+		FileImportResult: &FileImportResult{
+			Type:  "error",
+			Error: &FileImportError{Code: "download_failed", StatusCode: 404},
+		},
+	}
+	got := jsonRoundTrip(t, fu)
+	if got.ID != "fu-1" || got.Status != FileUploadStatusPending {
+		t.Errorf("unexpected FileUpload: %+v", got)
+	}
+	if got.NumberOfParts == nil || got.NumberOfParts.Total != 3 || got.NumberOfParts.SentCount != 1 {
+		t.Errorf("unexpected NumberOfParts: %+v", got.NumberOfParts)
+	}
+	if got.FileImportResult == nil || got.FileImportResult.Error == nil || got.FileImportResult.Error.StatusCode != 404 {
+		t.Errorf("unexpected FileImportResult: %+v", got.FileImportResult)
+	}
+	if got.ContentLength != 42 {
+		t.Errorf("expected ContentLength 42, got %d", got.ContentLength)
+	}
+}
+
+func TestProperty_UnknownFields(t *testing.T) {
+	data := `{"id":"p","name":"Where","place":{"zoom":3},"type":"place"}`
+	var prop Property
+	if err := json.Unmarshal([]byte(data), &prop); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if string(prop.Unknown["place"]) != `{"zoom":3}` {
+		t.Errorf("unexpected Unknown: %v", prop.Unknown)
+	}
+	got, err := json.Marshal(prop)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("round trip mismatch:\nwant %s\ngot  %s", data, got)
+	}
+}
+
+func TestValueProperty_UnknownFields(t *testing.T) {
+	data := `{"id":"p","place":{"lat":1.5,"lon":2},"type":"place"}`
+	var vp ValueProperty
+	if err := json.Unmarshal([]byte(data), &vp); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if string(vp.Unknown["place"]) != `{"lat":1.5,"lon":2}` {
+		t.Errorf("unexpected Unknown: %v", vp.Unknown)
+	}
+	got, err := json.Marshal(vp)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("round trip mismatch:\nwant %s\ngot  %s", data, got)
+	}
+
+	// Unknown fields next to a known type are kept as well.
+	data = `{"checkbox":true,"id":"c","new_flag":"x","type":"checkbox"}`
+	vp = ValueProperty{}
+	if err := json.Unmarshal([]byte(data), &vp); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	got, err = json.Marshal(vp)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(got) != data {
+		t.Errorf("round trip mismatch:\nwant %s\ngot  %s", data, got)
+	}
+}
+
+func TestPage_UnknownPropertyRoundTrip(t *testing.T) {
+	data := []byte(`{"object":"page","id":"page-1","properties":{"Where":{"id":"p","type":"place","place":{"name":"Home"}}}}`)
+	var pg Page
+	if err := json.Unmarshal(data, &pg); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	got := jsonRoundTrip(t, pg)
+	if string(got.Properties["Where"].Unknown["place"]) != `{"name":"Home"}` {
+		t.Errorf("unexpected Unknown: %v", got.Properties["Where"].Unknown)
+	}
+}
+
 // Variable verification tests
 
 func TestColorVariables(t *testing.T) {
@@ -1067,6 +1583,7 @@ func TestParentTypeVariables(t *testing.T) {
 	tests := map[string]ParentType{
 		"database_id": ParentTypeDatabase,
 		"page_id":     ParentTypePage,
+		"block_id":    ParentTypeBlock,
 		"workspace":   ParentTypeWorkspace,
 	}
 	for expected, got := range tests {
@@ -1098,6 +1615,9 @@ func TestValuePropertyTypeVariables(t *testing.T) {
 		"last_edited_time": ValuePropertyTypeLastEditedTime,
 		"last_edited_by":   ValuePropertyTypeLastEditedBy,
 		"status":           ValuePropertyTypeStatus,
+		"unique_id":        ValuePropertyTypeUniqueID,
+		"verification":     ValuePropertyTypeVerification,
+		"button":           ValuePropertyTypeButton,
 	}
 	for expected, got := range tests {
 		if string(got) != expected {
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaError is a property value that does not match the schema of the data source.
+type SchemaError struct {
+	// Path of the offending value, e.g. `Tags.multi_select[1]`.
+	Path string
+
+	Msg string
+}
+
+func (e *SchemaError) Error() string {
+	return e.Path + ": " + e.Msg
+}
+
+// SchemaErrors is returned by ValidatePage with every problem found in the page.
+type SchemaErrors []*SchemaError
+
+func (e SchemaErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, se := range e {
+		msgs[i] = se.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ValidatePage checks property values against the schema of their data source, as Notion does
+// when a page is created or updated, and returns SchemaErrors with every problem at once:
+// properties that are not in the schema, values whose type differs from the property, read-only
+// properties, select, multi select and status options that do not exist and dates that do not
+// parse. Properties can be keyed by name or by ID.
+func ValidatePage(props map[string]ValueProperty, schema map[string]Property) error {
+	var errs SchemaErrors
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, &SchemaError{Path: path, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	for _, key := range sortedKeys(props) {
+		vp := props[key]
+
+		name, prop, ok := lookupProperty(schema, key)
+		if !ok {
+			if similar := similarProperty(schema, key); similar != "" {
+				add(key, "unknown property, did you mean %q?", similar)
+			} else {
+				add(key, "unknown property")
+			}
+			continue
+		}
+
+		if vp.Type == "" {
+			add(name, "missing value type, the property is of type %s", prop.Type)
+			continue
+		}
+		if string(vp.Type) != prop.Type {
+			add(name, "value of type %s for a %s property", vp.Type, prop.Type)
+			continue
+		}
+		if _, writable := vp.writableValue(); !writable {
+			add(name, "%s properties are read-only", vp.Type)
+			continue
+		}
+
+		path := name + "." + string(vp.Type)
+		switch vp.Type {
+		case ValuePropertyTypeSelect:
+			if vp.Select != nil && !hasOption(schemaOptions(prop), *vp.Select) {
+				add(path, "unknown option %s", optionName(*vp.Select))
+			}
+		case ValuePropertyTypeStatus:
+			if vp.Status != nil && !hasOption(schemaOptions(prop), *vp.Status) {
+				add(path, "unknown option %s", optionName(*vp.Status))
+			}
+		case ValuePropertyTypeMultiSelect:
+			for i, o := range vp.MultiSelect {
+				if !hasOption(schemaOptions(prop), o) {
+					add(fmt.Sprintf("%s[%d]", path, i), "unknown option %s", optionName(o))
+				}
+			}
+		case ValuePropertyTypeDate:
+			if vp.Date == nil {
+				break
+			}
+			start, _, err := ParseDate(vp.Date.Start, vp.Date.TimeZone)
+			if err != nil {
+				add(path+".start", "%v", err)
+			}
+			if vp.Date.End != "" {
+				end, _, endErr := ParseDate(vp.Date.End, vp.Date.TimeZone)
+				switch {
+				case endErr != nil:
+					add(path+".end", "%v", endErr)
+				case err == nil && end.Before(start):
+					add(path+".end", "end %s is before start %s", vp.Date.End, vp.Date.Start)
+				}
+			}
+		case ValuePropertyTypeRelation:
+			for i, r := range vp.Relation {
+				if r.ID == "" {
+					add(fmt.Sprintf("%s[%d]", path, i), "missing page ID")
+				}
+			}
+		case ValuePropertyTypePeople:
+			for i, u := range vp.People {
+				if u.ID == "" {
+					add(fmt.Sprintf("%s[%d]", path, i), "missing user ID")
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// MissingOptions returns the names of the select and multi select options used in the property
+// values that do not exist in the schema yet, keyed by the name of the property. Status options
+// are not included, they can only be added in Notion.
+func MissingOptions(props map[string]ValueProperty, schema map[string]Property) map[string][]string {
+	ret := map[string][]string{}
+
+	for _, key := range sortedKeys(props) {
+		vp := props[key]
+
+		name, prop, ok := lookupProperty(schema, key)
+		if !ok || string(vp.Type) != prop.Type {
+			continue
+		}
+
+		var used []Option
+		switch {
+		case vp.Type == ValuePropertyTypeSelect && vp.Select != nil:
+			used = []Option{*vp.Select}
+		case vp.Type == ValuePropertyTypeMultiSelect:
+			used = vp.MultiSelect
+		}
+
+		for _, o := range used {
+			if o.Name != "" && !hasOption(schemaOptions(prop), o) && !contains(ret[name], o.Name) {
+				ret[name] = append(ret[name], o.Name)
+			}
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil
+	}
+
+	return ret
+}
+
+// lookupProperty finds a property of the schema by name or ID.
+func lookupProperty(schema map[string]Property, key string) (string, Property, bool) {
+	if p, ok := schema[key]; ok {
+		return key, p, true
+	}
+
+	for name, p := range schema {
+		if p.ID == key {
+			return name, p, true
+		}
+	}
+
+	return "", Property{}, false
+}
+
+// similarProperty returns the property whose name only differs from key by case or surrounding
+// spaces, a common cause of "property does not exist" errors.
+func similarProperty(schema map[string]Property, key string) string {
+	for name := range schema {
+		if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(key)) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func schemaOptions(p Property) []Option {
+	switch {
+	case p.Select != nil:
+		return p.Select.Options
+	case p.MultiSelect != nil:
+		return p.MultiSelect.Options
+	case p.Status != nil:
+		opts := make([]Option, len(p.Status.Options))
+		for i, o := range p.Status.Options {
+			opts[i] = Option{ID: o.ID, Name: o.Name}
+		}
+		return opts
+	}
+
+	return nil
+}
+
+// hasOption reports whether o matches one of the options by ID or, when it has none, by name.
+func hasOption(opts []Option, o Option) bool {
+	for _, so := range opts {
+		if (o.ID != "" && so.ID == o.ID) || (o.ID == "" && so.Name == o.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func optionName(o Option) string {
+	if o.ID != "" && o.Name == "" {
+		return "with ID " + o.ID
+	}
+
+	return fmt.Sprintf("%q", o.Name)
+}
+
+func sortedKeys(props map[string]ValueProperty) []string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
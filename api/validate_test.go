@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var validateSchema = map[string]Property{
+	"Name":     {ID: "title", Type: "title"},
+	"Pages":    {ID: "pg", Type: "number"},
+	"Category": {ID: "cat", Type: "select", Select: &Select{Options: []Option{{ID: "o1", Name: "Fiction"}}}},
+	"Tags":     {ID: "tg", Type: "multi_select", MultiSelect: &Select{Options: []Option{{Name: "go"}}}},
+	"Status":   {ID: "st", Type: "status", Status: &Status{Options: []StatusOption{{Name: "Done"}}}},
+	"Finished": {ID: "fin", Type: "date"},
+	"Authors":  {ID: "au", Type: "relation"},
+	"Total":    {ID: "tot", Type: "formula"},
+}
+
+func TestValidatePage(t *testing.T) {
+	valid := map[string]ValueProperty{
+		"title":    {Type: ValuePropertyTypeTitle, Title: []RichText{NewText("Dune")}},
+		"Pages":    {Type: ValuePropertyTypeNumber},
+		"Category": {Type: ValuePropertyTypeSelect, Select: &Option{ID: "o1"}},
+		"Tags":     {Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "go"}}},
+		"Status":   {Type: ValuePropertyTypeStatus, Status: &Option{Name: "Done"}},
+		"Finished": {Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2026-10-01", End: "2026-10-18"}},
+	}
+	if err := ValidatePage(valid, validateSchema); err != nil {
+		t.Errorf("expected valid page, got %v", err)
+	}
+
+	invalid := map[string]ValueProperty{
+		"name":     {Type: ValuePropertyTypeTitle},
+		"Pages":    {Type: ValuePropertyTypeRichText},
+		"Category": {Type: ValuePropertyTypeSelect, Select: &Option{Name: "Poetry"}},
+		"Tags":     {Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "go"}, {Name: "rust"}}},
+		"Status":   {Type: ValuePropertyTypeStatus, Status: &Option{ID: "nope"}},
+		"Finished": {Type: ValuePropertyTypeDate, Date: &DateRange{Start: "2026-10-18", End: "2026-10-01"}},
+		"Authors":  {Type: ValuePropertyTypeRelation, Relation: []Relation{{ID: "a1"}, {}}},
+		"tot":      {Type: ValuePropertyTypeFormula},
+		"Typo":     {},
+	}
+
+	err := ValidatePage(invalid, validateSchema)
+
+	var errs SchemaErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected SchemaErrors, got %v", err)
+	}
+
+	var got []string
+	for _, se := range errs {
+		got = append(got, se.Error())
+	}
+	want := []string{
+		`Authors.relation[1]: missing page ID`,
+		`Category.select: unknown option "Poetry"`,
+		`Finished.date.end: end 2026-10-01 is before start 2026-10-18`,
+		`Pages: value of type rich_text for a number property`,
+		`Status.status: unknown option with ID nope`,
+		`Tags.multi_select[1]: unknown option "rust"`,
+		`Typo: unknown property`,
+		`name: unknown property, did you mean "Name"?`,
+		`Total: formula properties are read-only`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestMissingOptions(t *testing.T) {
+	props := map[string]ValueProperty{
+		"cat":    {Type: ValuePropertyTypeSelect, Select: &Option{Name: "Poetry"}},
+		"Tags":   {Type: ValuePropertyTypeMultiSelect, MultiSelect: []Option{{Name: "go"}, {Name: "rust"}, {Name: "zig"}, {Name: "rust"}}},
+		"Status": {Type: ValuePropertyTypeStatus, Status: &Option{Name: "Archived"}},
+		"Pages":  {Type: ValuePropertyTypeSelect, Select: &Option{Name: "wrong type"}},
+	}
+
+	want := map[string][]string{"Category": {"Poetry"}, "Tags": {"rust", "zig"}}
+	if got := MissingOptions(props, validateSchema); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := MissingOptions(map[string]ValueProperty{}, validateSchema); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Command notion-gen writes a Go file with a struct, option constants and filter builders for the
+// schema of a Notion data source. The schema is read either from the API:
+//
+//	NOTION_TOKEN=... notion-gen -data-source <id> -package books -type Book -o book_gen.go
+//
+// or from a JSON dump of a data source or database object, e.g. one saved from the API:
+//
+//	notion-gen -schema books.json -package books -o book_gen.go
+//
+// It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/surajssd/libnotion/cmd/notion-gen -schema books.json -package books -o book_gen.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/pkg/codegen"
+	"github.com/surajssd/libnotion/pkg/rest"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "notion-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	dataSourceID := flag.String("data-source", "", "ID of the data source to read the schema from, using the token in $NOTION_TOKEN")
+	schemaFile := flag.String("schema", "", "JSON file with the data source to read the schema from")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file, defaults to $GOPACKAGE")
+	typeName := flag.String("type", "", "name of the generated struct, defaults to the title of the data source")
+	out := flag.String("o", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	var ds *api.DataSource
+	switch {
+	case *dataSourceID != "" && *schemaFile != "":
+		return fmt.Errorf("-data-source and -schema are mutually exclusive")
+	case *dataSourceID != "":
+		token := os.Getenv("NOTION_TOKEN")
+		if token == "" {
+			return fmt.Errorf("NOTION_TOKEN is not set")
+		}
+
+		var err error
+		ds, err = rest.NewNotionClient(rest.WithSecretToken(token)).GetDataSource(*dataSourceID)
+		if err != nil {
+			return err
+		}
+	case *schemaFile != "":
+		data, err := os.ReadFile(*schemaFile)
+		if err != nil {
+			return err
+		}
+
+		ds = &api.DataSource{}
+		if err := json.Unmarshal(data, ds); err != nil {
+			return fmt.Errorf("parsing %s: %w", *schemaFile, err)
+		}
+	default:
+		return fmt.Errorf("one of -data-source or -schema is required")
+	}
+
+	src, err := codegen.Generate(ds, codegen.Options{Package: *pkg, TypeName: *typeName})
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(*out, src, 0o644)
+}
@@ -0,0 +1,110 @@
+// Package rawjson keeps the fields of a JSON object that a struct does not know about, so that
+// they can be written back unchanged. It is used to preserve block and property types that Notion
+// adds after this library was written.
+package rawjson
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// knownFields caches the JSON field names of struct types, keyed by reflect.Type.
+var knownFields sync.Map
+
+// Unknown returns the fields of the JSON object in data that do not map to a field of the struct
+// v, or nil if there are none. Field names are matched case-insensitively, like encoding/json does.
+func Unknown(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	known := fieldsOf(reflect.TypeOf(v))
+
+	var ret map[string]json.RawMessage
+	for k, raw := range fields {
+		if known[strings.ToLower(k)] {
+			continue
+		}
+
+		if ret == nil {
+			ret = map[string]json.RawMessage{}
+		}
+		ret[k] = raw
+	}
+
+	return ret, nil
+}
+
+// Merge adds the unknown fields to the JSON object in data. Fields already present in data take
+// precedence over the unknown ones.
+func Merge(data []byte, unknown map[string]json.RawMessage) ([]byte, error) {
+	if len(unknown) == 0 {
+		return data, nil
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for k, raw := range unknown {
+		if _, ok := fields[k]; !ok {
+			fields[k] = raw
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// fieldsOf returns the lower-cased JSON field names of the struct type t, including the fields of
+// embedded structs.
+func fieldsOf(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if cached, ok := knownFields.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	ret := map[string]bool{}
+	addFields(t, ret)
+	knownFields.Store(t, ret)
+
+	return ret
+}
+
+func addFields(t reflect.Type, ret map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			addFields(ft, ret)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		ret[strings.ToLower(name)] = true
+	}
+}
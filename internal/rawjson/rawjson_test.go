@@ -0,0 +1,79 @@
+package rawjson
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type embedded struct {
+	ID string `json:"id,omitempty"`
+}
+
+type sample struct {
+	embedded `json:",inline"`
+	Type     string `json:"type,omitempty"`
+	Name     string
+	Skipped  string `json:"-"`
+	hidden   string
+}
+
+func TestUnknown(t *testing.T) {
+	data := []byte(`{"id":"1","type":"x","Name":"n","Skipped":"s","hidden":"h","new_type":{"a":1}}`)
+	got, err := Unknown(data, sample{})
+	if err != nil {
+		t.Fatalf("Unknown failed: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 unknown fields, got %v", got)
+	}
+	if string(got["new_type"]) != `{"a":1}` {
+		t.Errorf("unexpected new_type: %s", got["new_type"])
+	}
+	if _, ok := got["Skipped"]; !ok {
+		t.Error("expected fields tagged with - to be unknown")
+	}
+	if _, ok := got["hidden"]; !ok {
+		t.Error("expected unexported fields to be unknown")
+	}
+}
+
+func TestUnknown_CaseInsensitive(t *testing.T) {
+	got, err := Unknown([]byte(`{"TYPE":"x","name":"n"}`), &sample{})
+	if err != nil {
+		t.Fatalf("Unknown failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected no unknown fields, got %v", got)
+	}
+}
+
+func TestUnknown_NotAnObject(t *testing.T) {
+	if _, err := Unknown([]byte(`[1,2]`), sample{}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	unknown := map[string]json.RawMessage{
+		"new_type": json.RawMessage(`{"a":1}`),
+		"type":     json.RawMessage(`"stale"`),
+	}
+	got, err := Merge([]byte(`{"type":"x"}`), unknown)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if want := `{"new_type":{"a":1},"type":"x"}`; string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMerge_NoUnknown(t *testing.T) {
+	data := []byte(`{"type":"x"}`)
+	got, err := Merge(data, nil)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("expected %s, got %s", data, got)
+	}
+}
@@ -0,0 +1,302 @@
+// Package codegen writes Go code for the schema of a data source: a struct with `notion` tags for
+// api.MarshalPage and api.UnmarshalPage, constants for the select, multi select and status
+// options and typed filter builders for every property. Generated code turns a renamed property
+// or option into a compile error instead of a query that silently matches nothing.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// Options configures the generated code.
+type Options struct {
+	// Package name of the generated file. Required.
+	Package string
+
+	// TypeName is the name of the struct. Defaults to the title of the data source.
+	TypeName string
+
+	// Generator is the command named in the "Code generated" header. Defaults to "notion-gen".
+	Generator string
+}
+
+// property is a property of the schema with the Go names picked for it.
+type property struct {
+	api.Property
+	name    string
+	field   string
+	goType  string
+	builder string
+	options []option
+}
+
+type option struct {
+	name  string
+	ident string
+}
+
+// Generate returns the formatted Go source for the schema of the data source.
+func Generate(ds *api.DataSource, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	title := api.PlainText(ds.Title)
+
+	typeName := opts.TypeName
+	if typeName == "" {
+		typeName = GoName(title)
+	}
+	if typeName == "" {
+		return nil, fmt.Errorf("data source has no title, the type name is required")
+	}
+
+	generator := opts.Generator
+	if generator == "" {
+		generator = "notion-gen"
+	}
+
+	props := properties(ds.Properties, typeName)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by %s from the data source %q (%s). DO NOT EDIT.\n\n", generator, title, ds.ID)
+	fmt.Fprintf(&b, "package %s\n\n", opts.Package)
+
+	imports := []string{"github.com/surajssd/libnotion/api", "github.com/surajssd/libnotion/pkg/filter"}
+	for _, p := range props {
+		if strings.HasPrefix(p.goType, "time.") {
+			imports = append([]string{"time", ""}, imports...)
+			break
+		}
+	}
+	b.WriteString("import (\n")
+	for _, imp := range imports {
+		if imp == "" {
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(&b, "\t%q\n", imp)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// %sDataSourceID is the ID of the data source %q.\n", typeName, title)
+	fmt.Fprintf(&b, "const %sDataSourceID = %q\n\n", typeName, ds.ID)
+
+	fmt.Fprintf(&b, "// %s is a page of the data source %q.\n", typeName, title)
+	fmt.Fprintf(&b, "type %s struct {\n\tapi.CommonObject\n\n", typeName)
+	for _, p := range props {
+		if p.goType == "" {
+			fmt.Fprintf(&b, "\t// %s: %s properties are not supported.\n", p.name, p.Type)
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s %s `notion:%q`\n", p.field, p.goType, p.name+","+p.Type)
+	}
+	b.WriteString("}\n\n")
+
+	for _, p := range props {
+		if len(p.options) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "// Options of the %s property %q.\nconst (\n", strings.ReplaceAll(p.Type, "_", " "), p.name)
+		for _, o := range p.options {
+			fmt.Fprintf(&b, "\t%s = %q\n", o.ident, o.name)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&b, "// %sFilter holds the filter builders of the properties of %s.\n", typeName, typeName)
+	fmt.Fprintf(&b, "var %sFilter = struct {\n", typeName)
+	for _, p := range props {
+		if p.builder != "" {
+			fmt.Fprintf(&b, "\t%s filter.%s\n", p.field, builderType(p.builder))
+		}
+	}
+	b.WriteString("}{\n")
+	for _, p := range props {
+		if p.builder != "" {
+			fmt.Fprintf(&b, "\t%s: filter.Prop(%q)%s,\n", p.field, p.name, p.builder)
+		}
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting the generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// properties returns the properties sorted by name, with unique Go names.
+func properties(schema map[string]api.Property, typeName string) []property {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Fields and methods of the embedded CommonObject are reserved, so that e.g. an "ID" property
+	// becomes IDProperty instead of shadowing the ID of the page.
+	reserved := map[string]bool{"CommonObject": true}
+	common := reflect.TypeOf(&api.CommonObject{})
+	for i := 0; i < common.Elem().NumField(); i++ {
+		reserved[common.Elem().Field(i).Name] = true
+	}
+	for i := 0; i < common.NumMethod(); i++ {
+		reserved[common.Method(i).Name] = true
+	}
+
+	fields := map[string]bool{}
+	idents := map[string]bool{typeName: true, typeName + "Filter": true, typeName + "DataSourceID": true}
+
+	var ret []property
+	for _, name := range names {
+		p := property{Property: schema[name], name: name}
+		if p.Name != "" {
+			p.name = p.Name
+		}
+
+		field := GoName(p.name)
+		if field == "" || reserved[field] {
+			field += "Property"
+		}
+		p.field = unique(fields, field)
+		p.goType, p.builder = goType(p.Type)
+
+		var opts []api.Option
+		switch {
+		case p.Select != nil:
+			opts = p.Select.Options
+		case p.MultiSelect != nil:
+			opts = p.MultiSelect.Options
+		case p.Status != nil:
+			for _, o := range p.Status.Options {
+				opts = append(opts, api.Option{Name: o.Name})
+			}
+		}
+		for i, o := range opts {
+			ident := GoName(o.Name)
+			if ident == "" {
+				ident = "Option" + strconv.Itoa(i+1)
+			}
+			p.options = append(p.options, option{name: o.Name, ident: unique(idents, typeName+p.field+ident)})
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// unique returns name, or name followed by a number if it is already taken.
+func unique(taken map[string]bool, name string) string {
+	ret := name
+	for i := 2; taken[ret]; i++ {
+		ret = name + strconv.Itoa(i)
+	}
+	taken[ret] = true
+
+	return ret
+}
+
+// goType returns the type of the struct field and the filter builder call for a property type.
+func goType(typ string) (string, string) {
+	switch api.ValuePropertyType(typ) {
+	case api.ValuePropertyTypeTitle:
+		return "string", ".Title()"
+	case api.ValuePropertyTypeRichText:
+		return "string", ".RichText()"
+	case api.ValuePropertyTypeURL:
+		return "string", ".URL()"
+	case api.ValuePropertyTypeEmail:
+		return "string", ".Email()"
+	case api.ValuePropertyTypePhoneNumber:
+		return "string", ".PhoneNumber()"
+	case api.ValuePropertyTypeNumber:
+		return "*float64", ".Number()"
+	case api.ValuePropertyTypeCheckbox:
+		return "bool", ".Checkbox()"
+	case api.ValuePropertyTypeSelect:
+		return "string", ".Select()"
+	case api.ValuePropertyTypeStatus:
+		return "string", ".Status()"
+	case api.ValuePropertyTypeMultiSelect:
+		return "[]string", ".MultiSelect()"
+	case api.ValuePropertyTypeDate:
+		return "*api.DateRange", ".Date()"
+	case api.ValuePropertyTypeCreatedTime:
+		return "time.Time", ".CreatedTime()"
+	case api.ValuePropertyTypeLastEditedTime:
+		return "time.Time", ".LastEditedTime()"
+	case api.ValuePropertyTypeRelation:
+		return "[]string", ".Relation()"
+	case api.ValuePropertyTypePeople:
+		return "[]string", ".People()"
+	case api.ValuePropertyTypeCreatedBy:
+		return "string", ".CreatedBy()"
+	case api.ValuePropertyTypeLastEditedBy:
+		return "string", ".LastEditedBy()"
+	case api.ValuePropertyTypeFiles:
+		return "[]string", ".Files()"
+	case api.ValuePropertyTypeUniqueID:
+		return "string", ".UniqueID()"
+	case api.ValuePropertyTypeFormula:
+		return "api.ValueProperty", ".Formula()"
+	case api.ValuePropertyTypeRollup:
+		return "api.ValueProperty", ".Rollup()"
+	case api.ValuePropertyTypeVerification:
+		return "string", ""
+	}
+
+	return "", ""
+}
+
+// builderType returns the name of the filter type returned by a builder call.
+func builderType(call string) string {
+	switch call {
+	case ".Title()", ".RichText()", ".URL()", ".Email()", ".PhoneNumber()":
+		return "Text"
+	case ".CreatedTime()", ".LastEditedTime()":
+		return "Date"
+	case ".Relation()", ".People()", ".CreatedBy()", ".LastEditedBy()":
+		return "Contains"
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(call, "."), "()")
+}
+
+// GoName turns a property or option name into an exported Go identifier, e.g. "Due date" into
+// "DueDate" and "2024 goals" into "X2024Goals". It returns "" when the name has no letters or
+// digits.
+func GoName(s string) string {
+	var b strings.Builder
+
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		// Letters without case, e.g. in CJK names, would not be exported.
+		if b.Len() == 0 && !unicode.IsUpper(r) {
+			b.WriteString("X")
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
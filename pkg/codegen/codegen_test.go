@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func loadDataSource(t *testing.T) *api.DataSource {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/books.json")
+	if err != nil {
+		t.Fatalf("reading the schema: %v", err)
+	}
+
+	var ds api.DataSource
+	if err := json.Unmarshal(data, &ds); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	return &ds
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(loadDataSource(t), Options{Package: "books", TypeName: "Book"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "book_gen.go", src, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	got := string(src)
+	for _, want := range []string{
+		"// Code generated by notion-gen from the data source \"Books\" (ds-123). DO NOT EDIT.\n",
+		"package books\n",
+		"\t\"time\"\n",
+		"const BookDataSourceID = \"ds-123\"",
+		"type Book struct {\n\tapi.CommonObject\n",
+		"Added        time.Time      `notion:\"Added,created_time\"`",
+		"DateFinished *api.DateRange `notion:\"Date Finished,date\"`",
+		"// Go: button properties are not supported.",
+		"IDProperty  string            `notion:\"ID,unique_id\"`",
+		"Pages       *float64          `notion:\"Pages,number\"`",
+		"Score       api.ValueProperty `notion:\"Score,formula\"`",
+		"SubCategory []string          `notion:\"Sub Category,multi_select\"`",
+		"BookCategoryComputerScience = \"Computer Science\"",
+		"BookStatusNotStarted = \"Not started\"",
+		"BookSubCategoryOption2    = \"🔥\"",
+		"var BookFilter = struct {",
+		"Added:        filter.Prop(\"Added\").CreatedTime(),",
+		"Authors:      filter.Prop(\"Authors\").Relation(),",
+		"IDProperty:   filter.Prop(\"ID\").UniqueID(),",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected generated code to contain %q", want)
+		}
+	}
+
+	if strings.Contains(got, "filter.Prop(\"Verified\")") || strings.Contains(got, "filter.Prop(\"Go\")") {
+		t.Errorf("expected no filter builders for verification and button properties\n%s", got)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	ds := loadDataSource(t)
+	if _, err := Generate(ds, Options{TypeName: "Book"}); err == nil {
+		t.Error("expected error without a package name")
+	}
+
+	ds.Title = nil
+	if _, err := Generate(ds, Options{Package: "books"}); err == nil {
+		t.Error("expected error without a title or type name")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"Name":           "Name",
+		"Due date":       "DueDate",
+		"sub-category":   "SubCategory",
+		"2024 goals":     "X2024Goals",
+		"P0":             "P0",
+		"ça marche":      "ÇaMarche",
+		"名前":             "X名前",
+		"🔥":              "",
+		"  spaced  out ": "SpacedOut",
+	}
+	for in, want := range tests {
+		if got := GoName(in); got != want {
+			t.Errorf("GoName(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
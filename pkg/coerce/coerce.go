@@ -0,0 +1,396 @@
+// Package coerce converts plain strings, e.g. the cells of a CSV file or the fields of a form,
+// into property values typed after the schema of a data source:
+//
+//	props, err := coerce.Convert(map[string]string{
+//		"Name":     "Dune",
+//		"Pages":    "1,024",
+//		"Read":     "yes",
+//		"Tags":     "sci-fi, classic",
+//		"Finished": "March 3, 2026",
+//	}, ds.Properties)
+//
+// Relations and people are given by page title and email, resolved with the functions of a
+// Converter, see RelationsByTitle and PeopleByEmail.
+package coerce
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// DefaultDateLayouts are the layouts tried, in order, to parse dates. Layouts without a time
+// produce a date without a time.
+var DefaultDateLayouts = []string{
+	time.RFC3339Nano,
+	api.DateLayout,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02",
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/2006 15:04",
+	"1/2/2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"January 2, 2006 3:04 PM",
+	"Jan 2, 2006 3:04 PM",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// Converter converts strings into property values. The zero value converts everything but
+// relations given by title and people given by email.
+type Converter struct {
+	// Location of dates and times without an offset. Defaults to UTC.
+	Location *time.Location
+
+	// DateLayouts replaces DefaultDateLayouts, e.g. to read day first dates like 02/01/2006.
+	DateLayouts []string
+
+	// ResolveRelation returns the ID of the page with the given title in the related data source.
+	ResolveRelation func(dataSourceID, title string) (string, error)
+
+	// ResolvePerson returns the ID of the user with the given email.
+	ResolvePerson func(email string) (string, error)
+}
+
+// Convert converts values with the zero Converter.
+func Convert(values map[string]string, schema map[string]api.Property) (map[string]api.ValueProperty, error) {
+	return (&Converter{}).Convert(values, schema)
+}
+
+// Convert converts every value into a value of the type of its property in the schema, looked up
+// by name or ID. Empty strings clear the property, or uncheck a checkbox. Every value that cannot
+// be converted is reported at once as api.SchemaErrors, and the others are still returned.
+//
+// Values are read as follows:
+//   - numbers may have thousands separators, a currency sign or a percent sign, which divides
+//     them by 100 as Notion stores percents as fractions, see ParseNumber;
+//   - dates are parsed with DateLayouts, and ranges are written "start → end" or "start to end";
+//   - checkboxes accept true/false, yes/no, y/n, on/off, checked/unchecked, x and 1/0;
+//   - select, status and multi select options match the schema regardless of case, and multi
+//     select, relation, people and files values are comma-separated;
+//   - relations are page IDs or titles, people are user IDs or emails.
+func (c *Converter) Convert(values map[string]string, schema map[string]api.Property) (map[string]api.ValueProperty, error) {
+	ret := map[string]api.ValueProperty{}
+	var errs api.SchemaErrors
+
+	for _, key := range sortedKeys(values) {
+		name, prop, ok := lookup(schema, key)
+		if !ok {
+			errs = append(errs, &api.SchemaError{Path: key, Msg: "unknown property"})
+			continue
+		}
+
+		vp, err := c.convert(strings.TrimSpace(values[key]), prop)
+		if err != nil {
+			errs = append(errs, &api.SchemaError{Path: name, Msg: err.Error()})
+			continue
+		}
+
+		ret[name] = vp
+	}
+
+	if len(errs) > 0 {
+		return ret, errs
+	}
+
+	return ret, nil
+}
+
+func (c *Converter) convert(s string, prop api.Property) (api.ValueProperty, error) {
+	vp := api.ValueProperty{Type: api.ValuePropertyType(prop.Type)}
+
+	if s == "" {
+		switch vp.Type {
+		case api.ValuePropertyTypeTitle, api.ValuePropertyTypeRichText, api.ValuePropertyTypeNumber,
+			api.ValuePropertyTypeSelect, api.ValuePropertyTypeStatus, api.ValuePropertyTypeMultiSelect,
+			api.ValuePropertyTypeDate, api.ValuePropertyTypeCheckbox, api.ValuePropertyTypeURL,
+			api.ValuePropertyTypeEmail, api.ValuePropertyTypePhoneNumber, api.ValuePropertyTypeRelation,
+			api.ValuePropertyTypePeople, api.ValuePropertyTypeFiles:
+			return vp, nil
+		}
+	}
+
+	switch vp.Type {
+	case api.ValuePropertyTypeTitle:
+		vp.Title = []api.RichText{api.NewText(s)}
+	case api.ValuePropertyTypeRichText:
+		vp.RichText = []api.RichText{api.NewText(s)}
+	case api.ValuePropertyTypeNumber:
+		format := ""
+		if prop.Number != nil {
+			format = prop.Number.Format
+		}
+		n, err := ParseNumber(s, format)
+		if err != nil {
+			return vp, err
+		}
+		vp.Number = &n
+	case api.ValuePropertyTypeCheckbox:
+		b, err := ParseBool(s)
+		if err != nil {
+			return vp, err
+		}
+		vp.Checkbox = b
+	case api.ValuePropertyTypeSelect:
+		vp.Select = &api.Option{Name: optionName(prop.Select, s)}
+	case api.ValuePropertyTypeMultiSelect:
+		for _, item := range split(s) {
+			vp.MultiSelect = append(vp.MultiSelect, api.Option{Name: optionName(prop.MultiSelect, item)})
+		}
+	case api.ValuePropertyTypeStatus:
+		var sel api.Select
+		if prop.Status != nil {
+			for _, o := range prop.Status.Options {
+				sel.Options = append(sel.Options, api.Option{Name: o.Name})
+			}
+		}
+		vp.Status = &api.Option{Name: optionName(&sel, s)}
+	case api.ValuePropertyTypeDate:
+		d, err := c.parseDateRange(s)
+		if err != nil {
+			return vp, err
+		}
+		vp.Date = &d
+	case api.ValuePropertyTypeURL:
+		vp.URL = s
+	case api.ValuePropertyTypeEmail:
+		vp.Email = s
+	case api.ValuePropertyTypePhoneNumber:
+		vp.PhoneNumber = s
+	case api.ValuePropertyTypeRelation:
+		for _, item := range split(s) {
+			id, err := c.relation(prop, item)
+			if err != nil {
+				return vp, err
+			}
+			vp.Relation = append(vp.Relation, api.Relation{ID: id})
+		}
+	case api.ValuePropertyTypePeople:
+		for _, item := range split(s) {
+			id, err := c.person(item)
+			if err != nil {
+				return vp, err
+			}
+			vp.People = append(vp.People, api.User{Object: "user", ID: id})
+		}
+	case api.ValuePropertyTypeFiles:
+		for _, item := range split(s) {
+			vp.Files = append(vp.Files, api.File{Name: item, Type: "external", External: &api.External{URL: item}})
+		}
+	default:
+		return vp, fmt.Errorf("%s properties cannot be set", prop.Type)
+	}
+
+	return vp, nil
+}
+
+var numberReplacer = strings.NewReplacer("$", "", "€", "", "£", "", "¥", "", "₹", "", " ", "", "\u00a0", "", "_", "")
+
+// ParseNumber parses a number as it is commonly written: with thousands separators, a currency
+// sign, a percent sign or parentheses for negative amounts, e.g. "$1,234.50", "12.5%" or "(30)".
+// The format is the number format of the property. Notion writes every format but "number" with
+// commas as thousands separators, so they are read as such. With the "number" format a comma is
+// a decimal separator, e.g. in "1.234,5" or "12,5", when it is the last separator and is not
+// followed by exactly three digits.
+func ParseNumber(s, format string) (float64, error) {
+	orig := s
+	s = numberReplacer.Replace(strings.TrimSpace(s))
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s, negative = s[1:len(s)-1], true
+	}
+
+	percent := false
+	if strings.HasSuffix(s, "%") {
+		s, percent = strings.TrimSuffix(s, "%"), true
+	}
+
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case (format == "" || format == "number") &&
+		comma > dot && (dot >= 0 || strings.Count(s, ",") == 1 && len(s)-comma-1 != 3):
+		// Decimal comma, e.g. "1.234,5" or "12,5".
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	default:
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", orig)
+	}
+
+	if negative {
+		n = -n
+	}
+	if percent {
+		n /= 100
+	}
+
+	return n, nil
+}
+
+// ParseBool parses the usual ways of writing a checkbox value, ignoring case: true/false,
+// yes/no, y/n, on/off, checked/unchecked, x, ✓ and 1/0.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "yes", "y", "on", "checked", "x", "✓", "✔", "1":
+		return true, nil
+	case "false", "f", "no", "n", "off", "unchecked", "", "0":
+		return false, nil
+	}
+
+	return false, fmt.Errorf("invalid checkbox value %q", s)
+}
+
+var rangeSeparator = regexp.MustCompile(`\s+(→|->|to)\s+`)
+
+func (c *Converter) parseDateRange(s string) (api.DateRange, error) {
+	parts := rangeSeparator.Split(s, 2)
+
+	start, startTime, err := c.parseDate(parts[0])
+	if err != nil {
+		return api.DateRange{}, err
+	}
+	if len(parts) == 1 {
+		if startTime {
+			return api.NewDateTime(start), nil
+		}
+		return api.NewDate(start), nil
+	}
+
+	end, endTime, err := c.parseDate(parts[1])
+	if err != nil {
+		return api.DateRange{}, err
+	}
+
+	return api.NewDateRange(start, end, startTime || endTime), nil
+}
+
+// parseDate parses s with the first matching layout, and reports whether the layout has a time.
+func (c *Converter) parseDate(s string) (time.Time, bool, error) {
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	layouts := c.DateLayouts
+	if layouts == nil {
+		layouts = DefaultDateLayouts
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			// Layouts with an hour, minute or second have a time.
+			return t, strings.ContainsAny(layout, "345"), nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("invalid date %q", s)
+}
+
+// uuidPattern matches Notion IDs, with or without dashes.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+func (c *Converter) relation(prop api.Property, s string) (string, error) {
+	if uuidPattern.MatchString(s) {
+		return s, nil
+	}
+
+	if c.ResolveRelation == nil || prop.Relation == nil {
+		return "", fmt.Errorf("%q is not a page ID and relations cannot be resolved by title", s)
+	}
+
+	id, err := c.ResolveRelation(prop.Relation.DataSourceID, s)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", s, err)
+	}
+
+	return id, nil
+}
+
+func (c *Converter) person(s string) (string, error) {
+	if uuidPattern.MatchString(s) {
+		return s, nil
+	}
+
+	if !strings.Contains(s, "@") || c.ResolvePerson == nil {
+		return "", fmt.Errorf("%q is not a user ID and people cannot be resolved by email", s)
+	}
+
+	id, err := c.ResolvePerson(s)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", s, err)
+	}
+
+	return id, nil
+}
+
+// optionName returns the name of the option matching s regardless of case, or s if there is none.
+func optionName(sel *api.Select, s string) string {
+	if sel == nil {
+		return s
+	}
+
+	for _, o := range sel.Options {
+		if o.Name == s {
+			return s
+		}
+	}
+	for _, o := range sel.Options {
+		if strings.EqualFold(o.Name, s) {
+			return o.Name
+		}
+	}
+
+	return s
+}
+
+// split splits a comma-separated list, dropping empty items.
+func split(s string) []string {
+	var ret []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+
+	return ret
+}
+
+// lookup finds a property of the schema by name or ID.
+func lookup(schema map[string]api.Property, key string) (string, api.Property, bool) {
+	if p, ok := schema[key]; ok {
+		return key, p, true
+	}
+
+	for name, p := range schema {
+		if p.ID == key {
+			return name, p, true
+		}
+	}
+
+	return "", api.Property{}, false
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
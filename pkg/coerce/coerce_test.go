@@ -0,0 +1,205 @@
+package coerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+var coerceSchema = map[string]api.Property{
+	"Name":     {ID: "title", Type: "title"},
+	"Notes":    {Type: "rich_text"},
+	"Pages":    {Type: "number", Number: &api.Number{Format: "number"}},
+	"Price":    {Type: "number", Number: &api.Number{Format: "euro"}},
+	"Read":     {Type: "checkbox"},
+	"Category": {Type: "select", Select: &api.Select{Options: []api.Option{{Name: "Fiction"}}}},
+	"Tags":     {Type: "multi_select", MultiSelect: &api.Select{Options: []api.Option{{Name: "Sci-Fi"}}}},
+	"Status":   {Type: "status", Status: &api.Status{Options: []api.StatusOption{{Name: "In Progress"}}}},
+	"Finished": {Type: "date"},
+	"Link":     {Type: "url"},
+	"Authors":  {Type: "relation", Relation: &api.RelationConfig{DataSourceID: "authors"}},
+	"Owner":    {Type: "people"},
+	"Cover":    {Type: "files"},
+	"Total":    {Type: "formula"},
+}
+
+func TestConverter_Convert(t *testing.T) {
+	c := Converter{
+		ResolveRelation: func(dataSourceID, title string) (string, error) {
+			if dataSourceID != "authors" || title != "Frank Herbert" {
+				return "", fmt.Errorf("unexpected lookup %s/%s", dataSourceID, title)
+			}
+			return "author-1", nil
+		},
+		ResolvePerson: func(email string) (string, error) {
+			return "user-" + email, nil
+		},
+	}
+
+	got, err := c.Convert(map[string]string{
+		"title":    " Dune ",
+		"Notes":    "",
+		"Pages":    "1,024",
+		"Price":    "€1,299.50",
+		"Read":     "Yes",
+		"Category": "fiction",
+		"Tags":     "sci-fi, classic,",
+		"Status":   "in progress",
+		"Finished": "March 3, 2026 → Mar 5, 2026",
+		"Link":     "https://example.com",
+		"Authors":  "Frank Herbert, 1234abcd-0000-0000-0000-000000000000",
+		"Owner":    "ada@example.com",
+		"Cover":    "https://example.com/a.png",
+	}, coerceSchema)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	want := `{` +
+		`"Authors":{"relation":[{"id":"author-1"},{"id":"1234abcd-0000-0000-0000-000000000000"}],"type":"relation"},` +
+		`"Category":{"select":{"name":"Fiction"},"type":"select"},` +
+		`"Cover":{"files":[{"name":"https://example.com/a.png","type":"external","external":{"url":"https://example.com/a.png"}}],"type":"files"},` +
+		`"Finished":{"date":{"start":"2026-03-03","end":"2026-03-05"},"type":"date"},` +
+		`"Link":{"type":"url","url":"https://example.com"},` +
+		`"Name":{"title":[{"type":"text","text":{"content":"Dune"}}],"type":"title"},` +
+		`"Notes":{"rich_text":[],"type":"rich_text"},` +
+		`"Owner":{"people":[{"object":"user","id":"user-ada@example.com"}],"type":"people"},` +
+		`"Pages":{"number":1024,"type":"number"},` +
+		`"Price":{"number":1299.5,"type":"number"},` +
+		`"Read":{"checkbox":true,"type":"checkbox"},` +
+		`"Status":{"status":{"name":"In Progress"},"type":"status"},` +
+		`"Tags":{"multi_select":[{"name":"Sci-Fi"},{"name":"classic"}],"type":"multi_select"}` +
+		`}`
+	if string(data) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, data)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	got, err := Convert(map[string]string{
+		"Name":     "Dune",
+		"Pages":    "many",
+		"Read":     "maybe",
+		"Finished": "someday",
+		"Authors":  "Frank Herbert",
+		"Owner":    "ada@example.com",
+		"Total":    "3",
+		"Nope":     "x",
+	}, coerceSchema)
+
+	var errs api.SchemaErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected SchemaErrors, got %v", err)
+	}
+
+	want := []string{
+		`Authors: "Frank Herbert" is not a page ID and relations cannot be resolved by title`,
+		`Finished: invalid date "someday"`,
+		`Nope: unknown property`,
+		`Owner: "ada@example.com" is not a user ID and people cannot be resolved by email`,
+		`Pages: invalid number "many"`,
+		`Read: invalid checkbox value "maybe"`,
+		`Total: formula properties cannot be set`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Errorf("expected %q, got %q", want[i], errs[i].Error())
+		}
+	}
+
+	if _, ok := got["Name"]; !ok || len(got) != 1 {
+		t.Errorf("expected the valid values to be converted, got %v", got)
+	}
+}
+
+func TestConverter_Dates(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		c    Converter
+		in   string
+		want api.DateRange
+	}{
+		{Converter{}, "2026-03-03", api.DateRange{Start: "2026-03-03"}},
+		{Converter{}, "3/4/2026", api.DateRange{Start: "2026-03-04"}},
+		{Converter{}, "2026-03-03T10:00:00+02:00", api.DateRange{Start: "2026-03-03T10:00:00.000+02:00"}},
+		{Converter{Location: ny}, "2026-03-03 10:00", api.DateRange{Start: "2026-03-03T10:00:00.000-05:00"}},
+		{Converter{}, "Jan 2, 2026 3:04 PM to 2026-01-03", api.DateRange{Start: "2026-01-02T15:04:00.000Z", End: "2026-01-03T00:00:00.000Z"}},
+		{Converter{DateLayouts: []string{"02/01/2006"}}, "03/04/2026", api.DateRange{Start: "2026-04-03"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := tt.c.parseDateRange(tt.in)
+			if err != nil {
+				t.Fatalf("parseDateRange failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in, format string
+		want       float64
+	}{
+		{"42", "number", 42},
+		{"-3.5", "", -3.5},
+		{"1,234,567.89", "number", 1234567.89},
+		{"1.234,5", "number", 1234.5},
+		{"12,5", "number", 12.5},
+		{"12,500", "number", 12500},
+		{"12,5", "number_with_commas", 125},
+		{"$1,299.99", "dollar", 1299.99},
+		{"(30)", "dollar", -30},
+		{"12.5%", "percent", 0.125},
+		{"1 000", "number", 1000},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.in, tt.format)
+		if err != nil {
+			t.Errorf("ParseNumber(%q, %q) failed: %v", tt.in, tt.format, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseNumber(%q, %q): expected %v, got %v", tt.in, tt.format, tt.want, got)
+		}
+	}
+
+	if _, err := ParseNumber("1.2.3", "number"); err == nil {
+		t.Error("expected error for invalid number")
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for _, s := range []string{"true", "TRUE", "yes", "Y", "on", "checked", "x", "✓", "1"} {
+		if b, err := ParseBool(s); err != nil || !b {
+			t.Errorf("ParseBool(%q): expected true, got %v, %v", s, b, err)
+		}
+	}
+	for _, s := range []string{"false", "No", "n", "off", "unchecked", "", "0"} {
+		if b, err := ParseBool(s); err != nil || b {
+			t.Errorf("ParseBool(%q): expected false, got %v, %v", s, b, err)
+		}
+	}
+	if _, err := ParseBool("maybe"); err == nil {
+		t.Error("expected error for invalid value")
+	}
+}
@@ -0,0 +1,89 @@
+package coerce
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/pkg/rest"
+)
+
+// RelationsByTitle returns a Converter.ResolveRelation that finds the page with the given title
+// in the related data source. Every title is queried once, and a title matching no page or
+// several pages is an error.
+func RelationsByTitle(nc *rest.NotionClient) func(dataSourceID, title string) (string, error) {
+	var mu sync.Mutex
+	titleProps := map[string]string{}
+	ids := map[[2]string]string{}
+
+	return func(dataSourceID, title string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if id, ok := ids[[2]string{dataSourceID, title}]; ok {
+			return id, nil
+		}
+
+		titleProp, ok := titleProps[dataSourceID]
+		if !ok {
+			ds, err := nc.GetDataSource(dataSourceID)
+			if err != nil {
+				return "", err
+			}
+			for name, p := range ds.Properties {
+				if p.Type == string(api.ValuePropertyTypeTitle) {
+					titleProp = name
+				}
+			}
+			titleProps[dataSourceID] = titleProp
+		}
+
+		pages, err := nc.QueryDatabase(dataSourceID, &api.QueryDB{
+			Filter: &api.Filter{Property: titleProp, Title: &api.TextFilter{Equals: title}},
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(pages) != 1 {
+			return "", fmt.Errorf("found %d pages titled %q", len(pages), title)
+		}
+
+		ids[[2]string{dataSourceID, title}] = pages[0].ID
+
+		return pages[0].ID, nil
+	}
+}
+
+// PeopleByEmail returns a Converter.ResolvePerson that finds users by email, ignoring case. The
+// users of the workspace are listed once, on the first call.
+func PeopleByEmail(nc *rest.NotionClient) func(email string) (string, error) {
+	var mu sync.Mutex
+	var ids map[string]string
+
+	return func(email string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if ids == nil {
+			users, err := nc.ListUsers()
+			if err != nil {
+				return "", err
+			}
+
+			ids = map[string]string{}
+			for _, u := range users {
+				if u.Person != nil && u.Person.Email != "" {
+					ids[strings.ToLower(u.Person.Email)] = u.ID
+				}
+			}
+		}
+
+		id, ok := ids[strings.ToLower(email)]
+		if !ok {
+			return "", fmt.Errorf("no user with email %q", email)
+		}
+
+		return id, nil
+	}
+}
@@ -0,0 +1,90 @@
+package coerce
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/pkg/rest"
+)
+
+func TestRelationsByTitle(t *testing.T) {
+	queries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/data_sources/authors":
+			json.NewEncoder(w).Encode(api.DataSource{Properties: map[string]api.Property{
+				"Author": {Type: "title"},
+				"Born":   {Type: "date"},
+			}})
+		case "/v1/data_sources/authors/query":
+			queries++
+
+			var q api.QueryDB
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &q)
+			if q.Filter == nil || q.Filter.Property != "Author" || q.Filter.Title == nil {
+				t.Errorf("unexpected filter %s", body)
+				return
+			}
+
+			var pages []api.Page
+			switch q.Filter.Title.Equals {
+			case "Frank Herbert":
+				pages = []api.Page{{CommonObject: api.CommonObject{ID: "author-1"}}}
+			case "Anonymous":
+				pages = []api.Page{{}, {}}
+			}
+			json.NewEncoder(w).Encode(api.PageResponseList{Results: pages})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	resolve := RelationsByTitle(rest.NewNotionClient(rest.WithBaseURL(server.URL)))
+
+	for i := 0; i < 2; i++ {
+		id, err := resolve("authors", "Frank Herbert")
+		if err != nil || id != "author-1" {
+			t.Errorf("expected author-1, got %q, %v", id, err)
+		}
+	}
+	if queries != 1 {
+		t.Errorf("expected the title to be queried once, got %d queries", queries)
+	}
+
+	if _, err := resolve("authors", "Anonymous"); err == nil || err.Error() != `found 2 pages titled "Anonymous"` {
+		t.Errorf("expected error for an ambiguous title, got %v", err)
+	}
+	if _, err := resolve("authors", "Nobody"); err == nil {
+		t.Error("expected error for an unknown title")
+	}
+}
+
+func TestPeopleByEmail(t *testing.T) {
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		json.NewEncoder(w).Encode(api.UserResponseList{Results: []api.User{
+			{ID: "user-1", Type: "person", Person: &api.Person{Email: "Ada@example.com"}},
+			{ID: "bot-1", Type: "bot"},
+		}})
+	}))
+	defer server.Close()
+
+	resolve := PeopleByEmail(rest.NewNotionClient(rest.WithBaseURL(server.URL)))
+
+	if id, err := resolve("ada@EXAMPLE.com"); err != nil || id != "user-1" {
+		t.Errorf("expected user-1, got %q, %v", id, err)
+	}
+	if _, err := resolve("bob@example.com"); err == nil {
+		t.Error("expected error for an unknown email")
+	}
+	if lists != 1 {
+		t.Errorf("expected the users to be listed once, got %d requests", lists)
+	}
+}
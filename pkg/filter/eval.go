@@ -0,0 +1,722 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// Evaluator applies filters and sorts to pages locally, the way Notion applies them to a query:
+//
+//   - Text conditions compare the plain text of the value. Contains, starts with and ends with are
+//     case-insensitive, equals is not.
+//   - Empty values only match is_empty and the negative conditions, does_not_equal and
+//     does_not_contain.
+//   - Dates are compared by day when either the value or the condition has no time, and as
+//     instants otherwise. Date only values and relative windows, e.g. past_week, use Location.
+//   - Sorts put empty values last, whatever the direction.
+//
+// The zero value is ready to use.
+type Evaluator struct {
+	// Now returns the current time, for the relative date conditions. Defaults to time.Now.
+	Now func() time.Time
+
+	// Location is the time zone of relative date windows and of date times compared by day.
+	// Defaults to UTC.
+	Location *time.Location
+}
+
+// Match reports whether the page matches the filter, see Evaluator.
+func Match(f *api.Filter, pg api.Page) (bool, error) {
+	return Evaluator{}.Match(f, pg)
+}
+
+// Apply returns the pages matching the filter of the query, in the order of its sorts, see
+// Evaluator.
+func Apply(q *api.QueryDB, pages []api.Page) ([]api.Page, error) {
+	return Evaluator{}.Apply(q, pages)
+}
+
+// Match reports whether the page matches the filter. A nil filter matches every page.
+func (e Evaluator) Match(f *api.Filter, pg api.Page) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if err := f.Validate(); err != nil {
+		return false, err
+	}
+
+	return e.match(*f, pg)
+}
+
+// Apply returns the pages matching the filter of the query, in the order of its sorts. Pages
+// that compare equal keep their order. The page size and cursor of the query are ignored.
+func (e Evaluator) Apply(q *api.QueryDB, pages []api.Page) ([]api.Page, error) {
+	if q == nil {
+		return pages, nil
+	}
+
+	if q.Filter != nil {
+		if err := q.Filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []api.Page
+	for _, pg := range pages {
+		ok := true
+		if q.Filter != nil {
+			var err error
+			if ok, err = e.match(*q.Filter, pg); err != nil {
+				return nil, err
+			}
+		}
+
+		if ok {
+			ret = append(ret, pg)
+		}
+	}
+
+	if len(q.Sorts) == 0 {
+		return ret, nil
+	}
+
+	keys := make([][]sortKey, len(ret))
+	for i, pg := range ret {
+		for _, s := range q.Sorts {
+			k, err := e.sortKey(s, pg)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = append(keys[i], k)
+		}
+	}
+
+	idx := make([]int, len(ret))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	sort.SliceStable(idx, func(a, b int) bool {
+		for j, s := range q.Sorts {
+			c := keys[idx[a]][j].compare(keys[idx[b]][j], s.Direction != nil && *s.Direction == api.SortDirectionDescending)
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	sorted := make([]api.Page, len(ret))
+	for i, j := range idx {
+		sorted[i] = ret[j]
+	}
+
+	return sorted, nil
+}
+
+func (e Evaluator) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+func (e Evaluator) location() *time.Location {
+	if e.Location != nil {
+		return e.Location
+	}
+	return time.UTC
+}
+
+func (e Evaluator) match(f api.PropertyFilter, pg api.Page) (bool, error) {
+	switch {
+	case len(f.And) > 0:
+		for _, c := range f.And {
+			if ok, err := e.match(c, pg); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case len(f.Or) > 0:
+		for _, c := range f.Or {
+			if ok, err := e.match(c, pg); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case f.Timestamp != "":
+		return e.matchValue(f, api.ValueProperty{
+			Type:           api.ValuePropertyType(f.Timestamp),
+			CreatedTime:    pg.CreatedTime,
+			LastEditedTime: pg.LastEditedTime,
+		})
+	}
+
+	v, ok := lookupValue(pg, f.Property)
+	if !ok {
+		return false, fmt.Errorf("page %s has no property %q", pg.ID, f.Property)
+	}
+
+	return e.matchValue(f, v)
+}
+
+// lookupValue finds the property value by name, or else by ID.
+func lookupValue(pg api.Page, nameOrID string) (api.ValueProperty, bool) {
+	if v, ok := pg.Properties[nameOrID]; ok {
+		return v, true
+	}
+
+	for _, v := range pg.Properties {
+		if v.ID == nameOrID {
+			return v, true
+		}
+	}
+
+	return api.ValueProperty{}, false
+}
+
+func (e Evaluator) matchValue(f api.PropertyFilter, v api.ValueProperty) (bool, error) {
+	switch {
+	case f.Title != nil:
+		return matchText(*f.Title, api.PlainText(v.Title)), nil
+	case f.RichText != nil:
+		return matchText(*f.RichText, api.PlainText(v.RichText)), nil
+	case f.URL != nil:
+		return matchText(*f.URL, v.URL), nil
+	case f.Email != nil:
+		return matchText(*f.Email, v.Email), nil
+	case f.Phone != nil:
+		return matchText(*f.Phone, v.PhoneNumber), nil
+	case f.Number != nil:
+		return matchNumber(*f.Number, v.Number), nil
+	case f.Checkbox != nil:
+		return matchCheckbox(*f.Checkbox, v.Checkbox), nil
+	case f.Select != nil:
+		s := f.Select
+		return matchOption(s.Equals, s.DoesNotEqual, s.IsEmpty, s.IsNotEmpty, v.Select), nil
+	case f.Status != nil:
+		s := f.Status
+		return matchOption(s.Equals, s.DoesNotEqual, s.IsEmpty, s.IsNotEmpty, v.Status), nil
+	case f.MultiSelect != nil:
+		var names []string
+		for _, o := range v.MultiSelect {
+			names = append(names, o.Name)
+		}
+		m := f.MultiSelect
+		return matchContains(m.Contains, m.DoesNotContain, m.IsEmpty, m.IsNotEmpty, names, false), nil
+	case f.Date != nil:
+		return e.matchDate(*f.Date, v.Date)
+	case f.CreatedTime != nil:
+		return e.matchDate(*f.CreatedTime, timestamp(v.CreatedTime))
+	case f.LastEditedTime != nil:
+		return e.matchDate(*f.LastEditedTime, timestamp(v.LastEditedTime))
+	case f.Relation != nil:
+		var ids []string
+		for _, r := range v.Relation {
+			ids = append(ids, r.ID)
+		}
+		r := f.Relation
+		return matchContains(r.Contains, r.DoesNotContain, r.IsEmpty, r.IsNotEmpty, ids, true), nil
+	case f.People != nil:
+		return matchPeople(*f.People, v.People), nil
+	case f.CreatedBy != nil:
+		return matchPeople(*f.CreatedBy, userList(v.CreatedBy)), nil
+	case f.LastEditedBy != nil:
+		return matchPeople(*f.LastEditedBy, userList(v.LastEditedBy)), nil
+	case f.Files != nil:
+		return (f.Files.IsEmpty && len(v.Files) == 0) || (f.Files.IsNotEmpty && len(v.Files) > 0), nil
+	case f.Formula != nil:
+		return e.matchFormula(*f.Formula, v.Formula)
+	case f.Rollup != nil:
+		return e.matchRollup(*f.Rollup, v.Rollup)
+	case f.UniqueID != nil:
+		return matchUniqueID(*f.UniqueID, v.UniqueID), nil
+	case f.Verification != nil:
+		state := ""
+		if v.Verification != nil {
+			state = v.Verification.State
+		}
+		if f.Verification.Status == api.VerificationStatusNone {
+			return state == "" || state == "unverified", nil
+		}
+		return state == string(f.Verification.Status), nil
+	}
+
+	return false, fmt.Errorf("filter on property %q has no condition", f.Property)
+}
+
+func matchText(tf api.TextFilter, s string) bool {
+	ls := strings.ToLower(s)
+
+	switch {
+	case tf.IsEmpty:
+		return s == ""
+	case tf.IsNotEmpty:
+		return s != ""
+	case tf.DoesNotEqual != "":
+		return s != tf.DoesNotEqual
+	case tf.DoesNotContain != "":
+		return !strings.Contains(ls, strings.ToLower(tf.DoesNotContain))
+	case s == "":
+		return false
+	case tf.Equals != "":
+		return s == tf.Equals
+	case tf.Contains != "":
+		return strings.Contains(ls, strings.ToLower(tf.Contains))
+	case tf.StartsWith != "":
+		return strings.HasPrefix(ls, strings.ToLower(tf.StartsWith))
+	case tf.EndsWith != "":
+		return strings.HasSuffix(ls, strings.ToLower(tf.EndsWith))
+	}
+
+	return false
+}
+
+func matchNumber(nf api.NumberFilter, n *float64) bool {
+	switch {
+	case nf.IsEmpty:
+		return n == nil
+	case nf.IsNotEmpty:
+		return n != nil
+	case n == nil:
+		return nf.DoesNotEqual != nil
+	case nf.Equals != nil:
+		return *n == *nf.Equals
+	case nf.DoesNotEqual != nil:
+		return *n != *nf.DoesNotEqual
+	case nf.GreaterThan != nil:
+		return *n > *nf.GreaterThan
+	case nf.LessThan != nil:
+		return *n < *nf.LessThan
+	case nf.GreaterThanOrEqualTo != nil:
+		return *n >= *nf.GreaterThanOrEqualTo
+	case nf.LessThanOrEqualTo != nil:
+		return *n <= *nf.LessThanOrEqualTo
+	}
+
+	return false
+}
+
+func matchCheckbox(cf api.CheckboxFilter, b bool) bool {
+	if cf.Equals != nil {
+		return b == *cf.Equals
+	}
+
+	return cf.DoesNotEqual != nil && b != *cf.DoesNotEqual
+}
+
+func matchOption(equals, doesNotEqual string, isEmpty, isNotEmpty bool, o *api.Option) bool {
+	switch {
+	case isEmpty:
+		return o == nil
+	case isNotEmpty:
+		return o != nil
+	case doesNotEqual != "":
+		return o == nil || o.Name != doesNotEqual
+	case equals != "":
+		return o != nil && o.Name == equals
+	}
+
+	return false
+}
+
+// matchContains matches a list of values, e.g. the options of a multi-select or the page IDs of a
+// relation. IDs are compared without their dashes.
+func matchContains(contains, doesNotContain string, isEmpty, isNotEmpty bool, values []string, ids bool) bool {
+	has := func(want string) bool {
+		for _, v := range values {
+			if v == want || (ids && normalizeID(v) == normalizeID(want)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case isEmpty:
+		return len(values) == 0
+	case isNotEmpty:
+		return len(values) > 0
+	case doesNotContain != "":
+		return !has(doesNotContain)
+	case contains != "":
+		return has(contains)
+	}
+
+	return false
+}
+
+func normalizeID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+func matchPeople(pf api.PeopleFilter, users []api.User) bool {
+	var ids []string
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	return matchContains(pf.Contains, pf.DoesNotContain, pf.IsEmpty, pf.IsNotEmpty, ids, true)
+}
+
+func userList(u *api.User) []api.User {
+	if u == nil {
+		return nil
+	}
+	return []api.User{*u}
+}
+
+func matchUniqueID(uf api.UniqueIDFilter, u *api.UniqueIDValue) bool {
+	if u == nil {
+		return uf.DoesNotEqual != nil
+	}
+
+	n := u.Number
+	switch {
+	case uf.Equals != nil:
+		return n == *uf.Equals
+	case uf.DoesNotEqual != nil:
+		return n != *uf.DoesNotEqual
+	case uf.GreaterThan != nil:
+		return n > *uf.GreaterThan
+	case uf.LessThan != nil:
+		return n < *uf.LessThan
+	case uf.GreaterThanOrEqualTo != nil:
+		return n >= *uf.GreaterThanOrEqualTo
+	case uf.LessThanOrEqualTo != nil:
+		return n <= *uf.LessThanOrEqualTo
+	}
+
+	return false
+}
+
+func (e Evaluator) matchFormula(ff api.FormulaFilter, v *api.FormulaValue) (bool, error) {
+	if v == nil {
+		v = &api.FormulaValue{}
+	}
+
+	switch {
+	case ff.String != nil:
+		s := ""
+		if v.String != nil {
+			s = *v.String
+		}
+		return matchText(*ff.String, s), nil
+	case ff.Number != nil:
+		return matchNumber(*ff.Number, v.Number), nil
+	case ff.Checkbox != nil:
+		return matchCheckbox(*ff.Checkbox, v.Boolean != nil && *v.Boolean), nil
+	case ff.Date != nil:
+		return e.matchDate(*ff.Date, v.Date)
+	}
+
+	return false, nil
+}
+
+func (e Evaluator) matchRollup(rf api.RollupFilter, v *api.RollupValue) (bool, error) {
+	if v == nil {
+		v = &api.RollupValue{}
+	}
+
+	switch {
+	case rf.Number != nil:
+		return matchNumber(*rf.Number, v.Number), nil
+	case rf.Date != nil:
+		return e.matchDate(*rf.Date, v.Date)
+	}
+
+	cond, want := rf.Any, true
+	switch {
+	case rf.Every != nil:
+		cond, want = rf.Every, false
+	case rf.None != nil:
+		cond, want = rf.None, true
+	}
+
+	// any stops at the first match, every at the first mismatch and none at the first match.
+	for _, item := range v.Array {
+		ok, err := e.matchValue(*cond, item)
+		if err != nil {
+			return false, err
+		}
+
+		if ok == want {
+			return rf.Any != nil, nil
+		}
+	}
+
+	return rf.Any == nil, nil
+}
+
+func timestamp(s string) *api.DateRange {
+	if s == "" {
+		return nil
+	}
+	return &api.DateRange{Start: s}
+}
+
+func (e Evaluator) matchDate(df api.DateFilter, d *api.DateRange) (bool, error) {
+	empty := d == nil || d.Start == ""
+
+	switch {
+	case df.IsEmpty:
+		return empty, nil
+	case df.IsNotEmpty:
+		return !empty, nil
+	case empty:
+		return false, nil
+	}
+
+	t, hasTime, err := d.StartTime()
+	if err != nil {
+		return false, err
+	}
+
+	if from, to, ok := e.window(df); ok {
+		day := e.day(t, hasTime)
+		return !day.Before(from) && !day.After(to), nil
+	}
+
+	var op, value string
+	switch {
+	case df.Equals != "":
+		op, value = "equals", df.Equals
+	case df.Before != "":
+		op, value = "before", df.Before
+	case df.After != "":
+		op, value = "after", df.After
+	case df.OnOrBefore != "":
+		op, value = "on_or_before", df.OnOrBefore
+	case df.OnOrAfter != nil:
+		op, value = "on_or_after", *df.OnOrAfter
+	default:
+		return false, nil
+	}
+
+	ft, fHasTime, err := api.ParseDateIn(value, e.location())
+	if err != nil {
+		return false, err
+	}
+
+	var c int
+	if hasTime && fHasTime {
+		c = t.Compare(ft)
+	} else {
+		c = e.day(t, hasTime).Compare(e.day(ft, fHasTime))
+	}
+
+	switch op {
+	case "equals":
+		return c == 0, nil
+	case "before":
+		return c < 0, nil
+	case "after":
+		return c > 0, nil
+	case "on_or_before":
+		return c <= 0, nil
+	}
+
+	return c >= 0, nil
+}
+
+// day returns the calendar day of the time as midnight UTC. Date times are converted to the
+// location of the evaluator first, dates are taken as they are.
+func (e Evaluator) day(t time.Time, hasTime bool) time.Time {
+	if hasTime {
+		t = t.In(e.location())
+	}
+
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// window returns the first and last day of a relative date condition.
+func (e Evaluator) window(df api.DateFilter) (from, to time.Time, ok bool) {
+	today := e.day(e.now(), true)
+
+	switch {
+	case df.PastWeek != nil:
+		return today.AddDate(0, 0, -7), today, true
+	case df.PastMonth != nil:
+		return today.AddDate(0, -1, 0), today, true
+	case df.PastYear != nil:
+		return today.AddDate(-1, 0, 0), today, true
+	case df.NextWeek != nil:
+		return today, today.AddDate(0, 0, 7), true
+	case df.NextMonth != nil:
+		return today, today.AddDate(0, 1, 0), true
+	case df.NextYear != nil:
+		return today, today.AddDate(1, 0, 0), true
+	case df.ThisWeek != nil:
+		// Weeks start on Sunday, as in Notion.
+		start := today.AddDate(0, 0, -int(today.Weekday()))
+		return start, start.AddDate(0, 0, 6), true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// sortKey is the value a page is sorted by. Values are either numbers, which includes dates, or
+// strings.
+type sortKey struct {
+	empty bool
+	num   float64
+	str   string
+}
+
+// compare orders the keys, putting empty keys last whatever the direction.
+func (k sortKey) compare(o sortKey, desc bool) int {
+	switch {
+	case k.empty && o.empty:
+		return 0
+	case k.empty:
+		return 1
+	case o.empty:
+		return -1
+	}
+
+	c := 0
+	switch {
+	case k.num < o.num, k.num == o.num && k.str < o.str:
+		c = -1
+	case k.num > o.num, k.num == o.num && k.str > o.str:
+		c = 1
+	}
+
+	if desc {
+		return -c
+	}
+	return c
+}
+
+func (e Evaluator) sortKey(s api.Sort, pg api.Page) (sortKey, error) {
+	if s.Timestamp != nil {
+		ts := pg.CreatedTime
+		if *s.Timestamp == api.SortTimestampLastEditedTime {
+			ts = pg.LastEditedTime
+		}
+		return dateKey(timestamp(ts))
+	}
+
+	v, ok := lookupValue(pg, s.Property)
+	if !ok {
+		return sortKey{}, fmt.Errorf("page %s has no property %q", pg.ID, s.Property)
+	}
+
+	return valueKey(v)
+}
+
+func valueKey(v api.ValueProperty) (sortKey, error) {
+	str := func(s string) (sortKey, error) {
+		return sortKey{empty: s == "", str: strings.ToLower(s)}, nil
+	}
+	num := func(n *float64) (sortKey, error) {
+		if n == nil {
+			return sortKey{empty: true}, nil
+		}
+		return sortKey{num: *n}, nil
+	}
+	option := func(o *api.Option) (sortKey, error) {
+		if o == nil {
+			return sortKey{empty: true}, nil
+		}
+		return str(o.Name)
+	}
+	user := func(u *api.User) (sortKey, error) {
+		if u == nil {
+			return sortKey{empty: true}, nil
+		}
+		return str(u.Name)
+	}
+
+	switch v.Type {
+	case api.ValuePropertyTypeTitle:
+		return str(api.PlainText(v.Title))
+	case api.ValuePropertyTypeRichText:
+		return str(api.PlainText(v.RichText))
+	case api.ValuePropertyTypeURL:
+		return str(v.URL)
+	case api.ValuePropertyTypeEmail:
+		return str(v.Email)
+	case api.ValuePropertyTypePhoneNumber:
+		return str(v.PhoneNumber)
+	case api.ValuePropertyTypeNumber:
+		return num(v.Number)
+	case api.ValuePropertyTypeCheckbox:
+		if v.Checkbox {
+			return sortKey{num: 1}, nil
+		}
+		return sortKey{}, nil
+	case api.ValuePropertyTypeSelect:
+		return option(v.Select)
+	case api.ValuePropertyTypeStatus:
+		return option(v.Status)
+	case api.ValuePropertyTypeMultiSelect:
+		var names []string
+		for _, o := range v.MultiSelect {
+			names = append(names, o.Name)
+		}
+		return str(strings.Join(names, ", "))
+	case api.ValuePropertyTypeDate:
+		return dateKey(v.Date)
+	case api.ValuePropertyTypeCreatedTime:
+		return dateKey(timestamp(v.CreatedTime))
+	case api.ValuePropertyTypeLastEditedTime:
+		return dateKey(timestamp(v.LastEditedTime))
+	case api.ValuePropertyTypePeople:
+		if len(v.People) == 0 {
+			return sortKey{empty: true}, nil
+		}
+		return user(&v.People[0])
+	case api.ValuePropertyTypeCreatedBy:
+		return user(v.CreatedBy)
+	case api.ValuePropertyTypeLastEditedBy:
+		return user(v.LastEditedBy)
+	case api.ValuePropertyTypeUniqueID:
+		if v.UniqueID == nil {
+			return sortKey{empty: true}, nil
+		}
+		return sortKey{num: float64(v.UniqueID.Number)}, nil
+	case api.ValuePropertyTypeFormula:
+		if v.Formula == nil {
+			return sortKey{empty: true}, nil
+		}
+		switch {
+		case v.Formula.String != nil:
+			return str(*v.Formula.String)
+		case v.Formula.Number != nil:
+			return num(v.Formula.Number)
+		case v.Formula.Boolean != nil:
+			return valueKey(api.ValueProperty{Type: api.ValuePropertyTypeCheckbox, Checkbox: *v.Formula.Boolean})
+		}
+		return dateKey(v.Formula.Date)
+	case api.ValuePropertyTypeRollup:
+		if v.Rollup == nil {
+			return sortKey{empty: true}, nil
+		}
+		if v.Rollup.Date != nil {
+			return dateKey(v.Rollup.Date)
+		}
+		return num(v.Rollup.Number)
+	}
+
+	return sortKey{}, fmt.Errorf("cannot sort by %s property", v.Type)
+}
+
+func dateKey(d *api.DateRange) (sortKey, error) {
+	if d == nil || d.Start == "" {
+		return sortKey{empty: true}, nil
+	}
+
+	t, _, err := d.StartTime()
+	if err != nil {
+		return sortKey{}, err
+	}
+
+	return sortKey{num: float64(t.UnixMilli())}, nil
+}
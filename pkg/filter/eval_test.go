@@ -0,0 +1,206 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// evalNow is a Wednesday.
+var evalNow = time.Date(2026, 10, 21, 15, 0, 0, 0, time.UTC)
+
+func evalPage(id string, props map[string]api.ValueProperty) api.Page {
+	return api.Page{
+		CommonObject: api.CommonObject{ID: id, CreatedTime: "2026-10-01T10:00:00.000Z", LastEditedTime: "2026-10-20T10:00:00.000Z"},
+		Properties:   props,
+	}
+}
+
+var evalPg = evalPage("page-1", map[string]api.ValueProperty{
+	"Name":     {ID: "title", Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("Write "), api.NewText("The Docs")}},
+	"Notes":    {Type: api.ValuePropertyTypeRichText},
+	"Score":    {ID: "sc", Type: api.ValuePropertyTypeNumber, Number: api.Ptr(2.5)},
+	"Empty":    {Type: api.ValuePropertyTypeNumber},
+	"Done":     {Type: api.ValuePropertyTypeCheckbox, Checkbox: true},
+	"Priority": {Type: api.ValuePropertyTypeSelect, Select: &api.Option{Name: "P1"}},
+	"Status":   {Type: api.ValuePropertyTypeStatus, Status: &api.Option{Name: "In Progress"}},
+	"Tags":     {Type: api.ValuePropertyTypeMultiSelect, MultiSelect: []api.Option{{Name: "go"}, {Name: "docs"}}},
+	"Due":      {Type: api.ValuePropertyTypeDate, Date: &api.DateRange{Start: "2026-10-18"}},
+	"Meeting":  {Type: api.ValuePropertyTypeDate, Date: &api.DateRange{Start: "2026-10-22T23:30:00.000-04:00"}},
+	"Project":  {Type: api.ValuePropertyTypeRelation, Relation: []api.Relation{{ID: "1234abcd-0000-0000-0000-000000000000"}}},
+	"Owner":    {Type: api.ValuePropertyTypePeople, People: []api.User{{ID: "user-1", Name: "Ada"}}},
+	"Files":    {Type: api.ValuePropertyTypeFiles},
+	"Total":    {Type: api.ValuePropertyTypeFormula, Formula: &api.FormulaValue{Type: "number", Number: api.Ptr(10.0)}},
+	"Labels": {Type: api.ValuePropertyTypeRollup, Rollup: &api.RollupValue{Type: "array", Array: []api.ValueProperty{
+		{Type: api.ValuePropertyTypeRichText, RichText: []api.RichText{api.NewText("alpha")}},
+		{Type: api.ValuePropertyTypeRichText, RichText: []api.RichText{api.NewText("beta")}},
+	}}},
+	"ID": {Type: api.ValuePropertyTypeUniqueID, UniqueID: &api.UniqueIDValue{Number: 12}},
+})
+
+func TestEvaluator_Match(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"title contains is case-insensitive", Prop("Name").Title().Contains("the docs"), true},
+		{"title equals is exact", Prop("Name").Title().Equals("write the docs"), false},
+		{"title starts with", Prop("Name").Title().StartsWith("WRITE"), true},
+		{"rich text empty", Prop("Notes").RichText().IsEmpty(), true},
+		{"empty text does not contain", Prop("Notes").RichText().DoesNotContain("x"), true},
+		{"empty text does not match contains", Prop("Notes").RichText().Contains("x"), false},
+		{"number by ID", Prop("sc").Number().GreaterThan(2), true},
+		{"number equals", Prop("Score").Number().Equals(2.5), true},
+		{"empty number is not less", Prop("Empty").Number().LessThan(100), false},
+		{"empty number does not equal", Prop("Empty").Number().DoesNotEqual(1), true},
+		{"checkbox", Prop("Done").Checkbox().IsTrue(), true},
+		{"select in", Prop("Priority").Select().In("P0", "P1"), true},
+		{"status", Prop("Status").Status().DoesNotEqual("Done"), true},
+		{"multi select", Prop("Tags").MultiSelect().Contains("docs"), true},
+		{"multi select does not contain", Prop("Tags").MultiSelect().DoesNotContain("go"), false},
+		{"date before", Prop("Due").Date().Before(day(2026, 10, 19)), true},
+		{"date equals day", Prop("Due").Date().Equals(day(2026, 10, 18)), true},
+		{"date on or after", Prop("Due").Date().OnOrAfter(day(2026, 10, 18)), true},
+		{"date past week", Prop("Due").Date().PastWeek(), true},
+		{"date next week", Prop("Due").Date().NextWeek(), false},
+		{"date this week", Prop("Due").Date().ThisWeek(), true},
+		{"date time compared by day in UTC", Prop("Meeting").Date().Equals(day(2026, 10, 23)), true},
+		{"date time compared as instant", Prop("Meeting").Date().After(time.Date(2026, 10, 23, 3, 0, 0, 0, time.UTC)), true},
+		{"created time", CreatedTime().Before(day(2026, 10, 2)), true},
+		{"last edited time past week", LastEditedTime().PastWeek(), true},
+		{"relation ignores dashes", Prop("Project").Relation().Contains("1234abcd000000000000000000000000"), true},
+		{"people", Prop("Owner").People().Contains("user-2"), false},
+		{"files empty", Prop("Files").Files().IsEmpty(), true},
+		{"formula", Prop("Total").Formula().Number().GreaterThanOrEqualTo(10), true},
+		{"rollup any", Prop("Labels").Rollup().Any(Prop("").RichText().Contains("ALP")), true},
+		{"rollup every", Prop("Labels").Rollup().Every(Prop("").RichText().Contains("a")), true},
+		{"rollup none", Prop("Labels").Rollup().None(Prop("").RichText().Equals("beta")), false},
+		{"unique id", Prop("ID").UniqueID().LessThan(12), false},
+		{"and", Prop("Done").Checkbox().IsTrue().And(Prop("Score").Number().LessThan(2)), false},
+		{"or", Prop("Done").Checkbox().IsFalse().Or(Prop("Score").Number().LessThan(3)), true},
+	}
+
+	e := Evaluator{Now: func() time.Time { return evalNow }}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.Match(tt.cond.Filter(), evalPg)
+			if err != nil {
+				t.Fatalf("Match failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Location(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	e := Evaluator{Location: ny}
+	ok, err := e.Match(Prop("Meeting").Date().Equals(time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)).Filter(), evalPg)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected the meeting to be on the 22nd in New York")
+	}
+}
+
+func TestEvaluator_FixedZone(t *testing.T) {
+	e := Evaluator{Location: time.FixedZone("UTC-5", -5*60*60)}
+	ok, err := e.Match(Prop("Meeting").Date().Equals(time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC)).Filter(), evalPg)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected the meeting to be on the 22nd at UTC-5")
+	}
+}
+
+func TestEvaluator_MatchErrors(t *testing.T) {
+	if _, err := Match(Prop("Nope").Checkbox().IsTrue().Filter(), evalPg); err == nil {
+		t.Error("expected error for missing property")
+	}
+	if _, err := Match(&api.Filter{Property: "Done"}, evalPg); err == nil {
+		t.Error("expected error for invalid filter")
+	}
+	if ok, err := Match(nil, evalPg); err != nil || !ok {
+		t.Errorf("expected nil filter to match, got %v, %v", ok, err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	pages := []api.Page{
+		evalPage("a", map[string]api.ValueProperty{
+			"Name": {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("b")}},
+			"N":    {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(2.0)},
+			"Done": {Type: api.ValuePropertyTypeCheckbox},
+		}),
+		evalPage("b", map[string]api.ValueProperty{
+			"Name": {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("A")}},
+			"N":    {Type: api.ValuePropertyTypeNumber},
+			"Done": {Type: api.ValuePropertyTypeCheckbox},
+		}),
+		evalPage("c", map[string]api.ValueProperty{
+			"Name": {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("c")}},
+			"N":    {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(1.0)},
+			"Done": {Type: api.ValuePropertyTypeCheckbox, Checkbox: true},
+		}),
+		evalPage("d", map[string]api.ValueProperty{
+			"Name": {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("a")}},
+			"N":    {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(2.0)},
+			"Done": {Type: api.ValuePropertyTypeCheckbox},
+		}),
+	}
+
+	ids := func(pages []api.Page) string {
+		var s string
+		for _, pg := range pages {
+			s += pg.ID
+		}
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{"no filter or sort", NewQuery(), "abcd"},
+		{"filter", Where(Prop("Done").Checkbox().IsFalse()), "abd"},
+		{"asc puts empty last", NewQuery().OrderBy(Asc("N")), "cadb"},
+		{"desc puts empty last", NewQuery().OrderBy(Desc("N")), "adcb"},
+		{"secondary sort", NewQuery().OrderBy(Desc("N"), Asc("Name")), "dacb"},
+		{"text sort is case-insensitive and stable", NewQuery().OrderBy(Asc("Name")), "bdac"},
+		{"timestamp sort keeps order of equal keys", NewQuery().OrderBy(DescTimestamp(api.SortTimestampCreatedTime)), "abcd"},
+		{"filter and sort", Where(Prop("N").Number().IsNotEmpty()).OrderBy(Asc("N"), Desc("Name")), "cad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := tt.query.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+
+			got, err := Apply(q, pages)
+			if err != nil {
+				t.Fatalf("Apply failed: %v", err)
+			}
+			if ids(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, ids(got))
+			}
+		})
+	}
+
+	if _, err := Apply(&api.QueryDB{Sorts: []api.Sort{Asc("Nope")}}, pages); err == nil {
+		t.Error("expected error sorting by missing property")
+	}
+}
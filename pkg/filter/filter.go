@@ -0,0 +1,537 @@
+// Package filter builds database queries without writing api.Filter literals by hand.
+//
+//	q, err := filter.Where(
+//		filter.Prop("Done").Checkbox().IsTrue().And(filter.Prop("Due").Date().PastWeek()),
+//	).OrderBy(filter.Asc("Name")).Build()
+//
+// Conditions are combined with And and Or. Build validates the resulting filter and, when a
+// schema is given, checks it against the properties of the data source.
+//
+// Queries can also be written as text and compiled with Parse and ParseQuery:
+//
+//	q, err := filter.ParseQuery(`Done = false AND Due IN PAST WEEK ORDER BY Name`, schema)
+//
+// Apply and Match evaluate queries locally, e.g. over cached pages or in tests.
+package filter
+
+import (
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// Condition is a filter built by this package: a single property or timestamp condition, or a
+// compound of conditions. The zero value is not a valid condition.
+type Condition struct {
+	f api.PropertyFilter
+}
+
+// And returns a condition that matches when c and all the others match.
+func (c Condition) And(others ...Condition) Condition {
+	return And(append([]Condition{c}, others...)...)
+}
+
+// Or returns a condition that matches when c or any of the others match.
+func (c Condition) Or(others ...Condition) Condition {
+	return Or(append([]Condition{c}, others...)...)
+}
+
+// And returns a condition that matches when all the conditions match. Nested "and" conditions are
+// flattened, so that chaining And does not add levels of nesting.
+func And(conds ...Condition) Condition {
+	var fs []api.PropertyFilter
+	for _, c := range conds {
+		if len(c.f.And) > 0 {
+			fs = append(fs, c.f.And...)
+			continue
+		}
+
+		fs = append(fs, c.f)
+	}
+
+	return Condition{f: api.PropertyFilter{And: fs}}
+}
+
+// Or returns a condition that matches when any of the conditions match. Nested "or" conditions
+// are flattened, so that chaining Or does not add levels of nesting.
+func Or(conds ...Condition) Condition {
+	var fs []api.PropertyFilter
+	for _, c := range conds {
+		if len(c.f.Or) > 0 {
+			fs = append(fs, c.f.Or...)
+			continue
+		}
+
+		fs = append(fs, c.f)
+	}
+
+	return Condition{f: api.PropertyFilter{Or: fs}}
+}
+
+// Filter returns the condition as an api.Filter, without validating it.
+func (c Condition) Filter() *api.Filter {
+	f := c.f
+	return &f
+}
+
+// Build validates the condition and returns it as an api.Filter.
+func (c Condition) Build() (*api.Filter, error) {
+	if err := c.f.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c.Filter(), nil
+}
+
+// Property is a property of the data source to filter on. Pick the condition matching the type of
+// the property, e.g. Prop("Done").Checkbox().
+type Property struct {
+	name string
+}
+
+// Prop returns the property with the given name or ID.
+func Prop(name string) Property {
+	return Property{name: name}
+}
+
+func (p Property) cond(set func(*api.PropertyFilter)) Condition {
+	c := Condition{f: api.PropertyFilter{Property: p.name}}
+	set(&c.f)
+	return c
+}
+
+// Title filters on a title property.
+func (p Property) Title() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Title = tf })
+	}}
+}
+
+// RichText filters on a rich text property.
+func (p Property) RichText() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.RichText = tf })
+	}}
+}
+
+// URL filters on a URL property.
+func (p Property) URL() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.URL = tf })
+	}}
+}
+
+// Email filters on an email property.
+func (p Property) Email() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Email = tf })
+	}}
+}
+
+// PhoneNumber filters on a phone number property.
+func (p Property) PhoneNumber() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Phone = tf })
+	}}
+}
+
+// Number filters on a number property.
+func (p Property) Number() Number {
+	return Number{wrap: func(nf *api.NumberFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Number = nf })
+	}}
+}
+
+// Checkbox filters on a checkbox property.
+func (p Property) Checkbox() Checkbox {
+	return Checkbox{wrap: func(cf *api.CheckboxFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Checkbox = cf })
+	}}
+}
+
+// Select filters on a select property.
+func (p Property) Select() Select {
+	return Select{wrap: func(sf *api.SelectFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Select = sf })
+	}}
+}
+
+// Status filters on a status property.
+func (p Property) Status() Status {
+	return Status{p: p}
+}
+
+// MultiSelect filters on a multi-select property.
+func (p Property) MultiSelect() MultiSelect {
+	return MultiSelect{p: p}
+}
+
+// Date filters on a date property.
+func (p Property) Date() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.Date = df })
+	}}
+}
+
+// CreatedTime filters on a created time property. To filter on the creation time of pages without
+// such a property, use the CreatedTime function.
+func (p Property) CreatedTime() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.CreatedTime = df })
+	}}
+}
+
+// LastEditedTime filters on a last edited time property. To filter on the last edit time of pages
+// without such a property, use the LastEditedTime function.
+func (p Property) LastEditedTime() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition {
+		return p.cond(func(f *api.PropertyFilter) { f.LastEditedTime = df })
+	}}
+}
+
+// Relation filters on a relation property.
+func (p Property) Relation() Contains {
+	return Contains{wrap: func(contains, doesNotContain string, isEmpty, isNotEmpty bool) Condition {
+		return p.cond(func(f *api.PropertyFilter) {
+			f.Relation = &api.RelationFilter{Contains: contains, DoesNotContain: doesNotContain,
+				IsEmpty: isEmpty, IsNotEmpty: isNotEmpty}
+		})
+	}}
+}
+
+// People filters on a people property.
+func (p Property) People() Contains {
+	return p.people(func(f *api.PropertyFilter, pf *api.PeopleFilter) { f.People = pf })
+}
+
+// CreatedBy filters on a created by property.
+func (p Property) CreatedBy() Contains {
+	return p.people(func(f *api.PropertyFilter, pf *api.PeopleFilter) { f.CreatedBy = pf })
+}
+
+// LastEditedBy filters on a last edited by property.
+func (p Property) LastEditedBy() Contains {
+	return p.people(func(f *api.PropertyFilter, pf *api.PeopleFilter) { f.LastEditedBy = pf })
+}
+
+func (p Property) people(set func(*api.PropertyFilter, *api.PeopleFilter)) Contains {
+	return Contains{wrap: func(contains, doesNotContain string, isEmpty, isNotEmpty bool) Condition {
+		return p.cond(func(f *api.PropertyFilter) {
+			set(f, &api.PeopleFilter{Contains: contains, DoesNotContain: doesNotContain,
+				IsEmpty: isEmpty, IsNotEmpty: isNotEmpty})
+		})
+	}}
+}
+
+// Files filters on a files property.
+func (p Property) Files() Files {
+	return Files{p: p}
+}
+
+// Formula filters on a formula property.
+func (p Property) Formula() Formula {
+	return Formula{p: p}
+}
+
+// Rollup filters on a rollup property.
+func (p Property) Rollup() Rollup {
+	return Rollup{p: p}
+}
+
+// UniqueID filters on a unique ID property, by the number part of the ID.
+func (p Property) UniqueID() UniqueID {
+	return UniqueID{p: p}
+}
+
+// Verification matches wiki pages with the given verification status.
+func (p Property) Verification(status api.VerificationStatus) Condition {
+	return p.cond(func(f *api.PropertyFilter) {
+		f.Verification = &api.VerificationFilter{Status: status}
+	})
+}
+
+// CreatedTime filters on the creation time of the page.
+func CreatedTime() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition {
+		return Condition{f: api.PropertyFilter{Timestamp: api.FilterTimestampCreatedTime, CreatedTime: df}}
+	}}
+}
+
+// LastEditedTime filters on the last edit time of the page.
+func LastEditedTime() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition {
+		return Condition{f: api.PropertyFilter{Timestamp: api.FilterTimestampLastEditedTime, LastEditedTime: df}}
+	}}
+}
+
+// Text builds conditions on title, rich text, URL, email and phone number properties.
+type Text struct {
+	wrap func(*api.TextFilter) Condition
+}
+
+func (t Text) Equals(s string) Condition         { return t.wrap(&api.TextFilter{Equals: s}) }
+func (t Text) DoesNotEqual(s string) Condition   { return t.wrap(&api.TextFilter{DoesNotEqual: s}) }
+func (t Text) Contains(s string) Condition       { return t.wrap(&api.TextFilter{Contains: s}) }
+func (t Text) DoesNotContain(s string) Condition { return t.wrap(&api.TextFilter{DoesNotContain: s}) }
+func (t Text) StartsWith(s string) Condition     { return t.wrap(&api.TextFilter{StartsWith: s}) }
+func (t Text) EndsWith(s string) Condition       { return t.wrap(&api.TextFilter{EndsWith: s}) }
+func (t Text) IsEmpty() Condition                { return t.wrap(&api.TextFilter{IsEmpty: true}) }
+func (t Text) IsNotEmpty() Condition             { return t.wrap(&api.TextFilter{IsNotEmpty: true}) }
+
+// Number builds conditions on number properties, and on number formulas and rollups.
+type Number struct {
+	wrap func(*api.NumberFilter) Condition
+}
+
+func (n Number) Equals(v float64) Condition       { return n.wrap(&api.NumberFilter{Equals: &v}) }
+func (n Number) DoesNotEqual(v float64) Condition { return n.wrap(&api.NumberFilter{DoesNotEqual: &v}) }
+func (n Number) GreaterThan(v float64) Condition  { return n.wrap(&api.NumberFilter{GreaterThan: &v}) }
+func (n Number) LessThan(v float64) Condition     { return n.wrap(&api.NumberFilter{LessThan: &v}) }
+func (n Number) IsEmpty() Condition               { return n.wrap(&api.NumberFilter{IsEmpty: true}) }
+func (n Number) IsNotEmpty() Condition            { return n.wrap(&api.NumberFilter{IsNotEmpty: true}) }
+
+func (n Number) GreaterThanOrEqualTo(v float64) Condition {
+	return n.wrap(&api.NumberFilter{GreaterThanOrEqualTo: &v})
+}
+
+func (n Number) LessThanOrEqualTo(v float64) Condition {
+	return n.wrap(&api.NumberFilter{LessThanOrEqualTo: &v})
+}
+
+// Checkbox builds conditions on checkbox properties and checkbox formulas.
+type Checkbox struct {
+	wrap func(*api.CheckboxFilter) Condition
+}
+
+// IsTrue matches checked boxes.
+func (c Checkbox) IsTrue() Condition {
+	return c.wrap(&api.CheckboxFilter{Equals: api.Ptr(true)})
+}
+
+// IsFalse matches unchecked boxes.
+func (c Checkbox) IsFalse() Condition {
+	return c.wrap(&api.CheckboxFilter{Equals: api.Ptr(false)})
+}
+
+// Select builds conditions on select properties.
+type Select struct {
+	wrap func(*api.SelectFilter) Condition
+}
+
+func (s Select) Equals(option string) Condition { return s.wrap(&api.SelectFilter{Equals: option}) }
+func (s Select) IsEmpty() Condition             { return s.wrap(&api.SelectFilter{IsEmpty: true}) }
+func (s Select) IsNotEmpty() Condition          { return s.wrap(&api.SelectFilter{IsNotEmpty: true}) }
+
+func (s Select) DoesNotEqual(option string) Condition {
+	return s.wrap(&api.SelectFilter{DoesNotEqual: option})
+}
+
+// In matches any of the options. It is a shorthand for an "or" of Equals.
+func (s Select) In(options ...string) Condition {
+	var conds []Condition
+	for _, o := range options {
+		conds = append(conds, s.Equals(o))
+	}
+
+	return Or(conds...)
+}
+
+// Status builds conditions on status properties.
+type Status struct {
+	p Property
+}
+
+func (s Status) wrap(sf *api.StatusFilter) Condition {
+	return s.p.cond(func(f *api.PropertyFilter) { f.Status = sf })
+}
+
+func (s Status) Equals(option string) Condition { return s.wrap(&api.StatusFilter{Equals: option}) }
+func (s Status) IsEmpty() Condition             { return s.wrap(&api.StatusFilter{IsEmpty: true}) }
+func (s Status) IsNotEmpty() Condition          { return s.wrap(&api.StatusFilter{IsNotEmpty: true}) }
+
+func (s Status) DoesNotEqual(option string) Condition {
+	return s.wrap(&api.StatusFilter{DoesNotEqual: option})
+}
+
+// In matches any of the options. It is a shorthand for an "or" of Equals.
+func (s Status) In(options ...string) Condition {
+	var conds []Condition
+	for _, o := range options {
+		conds = append(conds, s.Equals(o))
+	}
+
+	return Or(conds...)
+}
+
+// MultiSelect builds conditions on multi-select properties.
+type MultiSelect struct {
+	p Property
+}
+
+func (m MultiSelect) wrap(mf *api.MultiSelectFilter) Condition {
+	return m.p.cond(func(f *api.PropertyFilter) { f.MultiSelect = mf })
+}
+
+func (m MultiSelect) Contains(option string) Condition {
+	return m.wrap(&api.MultiSelectFilter{Contains: option})
+}
+
+func (m MultiSelect) DoesNotContain(option string) Condition {
+	return m.wrap(&api.MultiSelectFilter{DoesNotContain: option})
+}
+
+func (m MultiSelect) IsEmpty() Condition    { return m.wrap(&api.MultiSelectFilter{IsEmpty: true}) }
+func (m MultiSelect) IsNotEmpty() Condition { return m.wrap(&api.MultiSelectFilter{IsNotEmpty: true}) }
+
+// Date builds conditions on dates, and on the creation and last edit times of pages.
+//
+// A time that is midnight in its location is sent as a date, e.g. "2026-11-01", any other time is
+// sent as a date time.
+type Date struct {
+	wrap func(*api.DateFilter) Condition
+}
+
+func (d Date) Equals(t time.Time) Condition { return d.wrap(&api.DateFilter{Equals: formatDate(t)}) }
+func (d Date) Before(t time.Time) Condition { return d.wrap(&api.DateFilter{Before: formatDate(t)}) }
+func (d Date) After(t time.Time) Condition  { return d.wrap(&api.DateFilter{After: formatDate(t)}) }
+func (d Date) IsEmpty() Condition           { return d.wrap(&api.DateFilter{IsEmpty: true}) }
+func (d Date) IsNotEmpty() Condition        { return d.wrap(&api.DateFilter{IsNotEmpty: true}) }
+func (d Date) PastWeek() Condition          { return d.wrap(&api.DateFilter{PastWeek: &api.EmptyConfig{}}) }
+func (d Date) PastMonth() Condition         { return d.wrap(&api.DateFilter{PastMonth: &api.EmptyConfig{}}) }
+func (d Date) PastYear() Condition          { return d.wrap(&api.DateFilter{PastYear: &api.EmptyConfig{}}) }
+func (d Date) ThisWeek() Condition          { return d.wrap(&api.DateFilter{ThisWeek: &api.EmptyConfig{}}) }
+func (d Date) NextWeek() Condition          { return d.wrap(&api.DateFilter{NextWeek: &api.EmptyConfig{}}) }
+func (d Date) NextMonth() Condition         { return d.wrap(&api.DateFilter{NextMonth: &api.EmptyConfig{}}) }
+func (d Date) NextYear() Condition          { return d.wrap(&api.DateFilter{NextYear: &api.EmptyConfig{}}) }
+
+func (d Date) OnOrBefore(t time.Time) Condition {
+	return d.wrap(&api.DateFilter{OnOrBefore: formatDate(t)})
+}
+
+func (d Date) OnOrAfter(t time.Time) Condition {
+	return d.wrap(&api.DateFilter{OnOrAfter: api.Ptr(formatDate(t))})
+}
+
+func formatDate(t time.Time) string {
+	if t.Equal(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())) {
+		return api.NewDate(t).Start
+	}
+
+	return api.NewDateTime(t).Start
+}
+
+// Contains builds conditions on relation and people properties. The values are page and user IDs.
+type Contains struct {
+	wrap func(contains, doesNotContain string, isEmpty, isNotEmpty bool) Condition
+}
+
+func (c Contains) Contains(id string) Condition       { return c.wrap(id, "", false, false) }
+func (c Contains) DoesNotContain(id string) Condition { return c.wrap("", id, false, false) }
+func (c Contains) IsEmpty() Condition                 { return c.wrap("", "", true, false) }
+func (c Contains) IsNotEmpty() Condition              { return c.wrap("", "", false, true) }
+
+// Files builds conditions on files properties.
+type Files struct {
+	p Property
+}
+
+func (f Files) IsEmpty() Condition {
+	return f.p.cond(func(pf *api.PropertyFilter) { pf.Files = &api.FilesFilter{IsEmpty: true} })
+}
+
+func (f Files) IsNotEmpty() Condition {
+	return f.p.cond(func(pf *api.PropertyFilter) { pf.Files = &api.FilesFilter{IsNotEmpty: true} })
+}
+
+// Formula builds conditions on formula properties. Pick the condition matching the type of the
+// formula result.
+type Formula struct {
+	p Property
+}
+
+func (f Formula) cond(ff api.FormulaFilter) Condition {
+	return f.p.cond(func(pf *api.PropertyFilter) { pf.Formula = &ff })
+}
+
+// Text builds conditions on formulas that return a string.
+func (f Formula) Text() Text {
+	return Text{wrap: func(tf *api.TextFilter) Condition { return f.cond(api.FormulaFilter{String: tf}) }}
+}
+
+// Number builds conditions on formulas that return a number.
+func (f Formula) Number() Number {
+	return Number{wrap: func(nf *api.NumberFilter) Condition { return f.cond(api.FormulaFilter{Number: nf}) }}
+}
+
+// Checkbox builds conditions on formulas that return a boolean.
+func (f Formula) Checkbox() Checkbox {
+	return Checkbox{wrap: func(cf *api.CheckboxFilter) Condition { return f.cond(api.FormulaFilter{Checkbox: cf}) }}
+}
+
+// Date builds conditions on formulas that return a date.
+func (f Formula) Date() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition { return f.cond(api.FormulaFilter{Date: df}) }}
+}
+
+// Rollup builds conditions on rollup properties.
+type Rollup struct {
+	p Property
+}
+
+func (r Rollup) cond(rf api.RollupFilter) Condition {
+	return r.p.cond(func(pf *api.PropertyFilter) { pf.Rollup = &rf })
+}
+
+// Any matches array rollups where any rolled up value matches. The condition is built on a
+// placeholder property, e.g. r.Any(filter.Prop("").RichText().Contains("x")); its name is ignored.
+func (r Rollup) Any(c Condition) Condition {
+	return r.cond(api.RollupFilter{Any: rollupValue(c)})
+}
+
+// Every matches array rollups where every rolled up value matches, see Any.
+func (r Rollup) Every(c Condition) Condition {
+	return r.cond(api.RollupFilter{Every: rollupValue(c)})
+}
+
+// None matches array rollups where no rolled up value matches, see Any.
+func (r Rollup) None(c Condition) Condition {
+	return r.cond(api.RollupFilter{None: rollupValue(c)})
+}
+
+func rollupValue(c Condition) *api.PropertyFilter {
+	f := c.f
+	f.Property = ""
+	return &f
+}
+
+// Number builds conditions on number rollups.
+func (r Rollup) Number() Number {
+	return Number{wrap: func(nf *api.NumberFilter) Condition { return r.cond(api.RollupFilter{Number: nf}) }}
+}
+
+// Date builds conditions on date rollups.
+func (r Rollup) Date() Date {
+	return Date{wrap: func(df *api.DateFilter) Condition { return r.cond(api.RollupFilter{Date: df}) }}
+}
+
+// UniqueID builds conditions on unique ID properties.
+type UniqueID struct {
+	p Property
+}
+
+func (u UniqueID) wrap(uf *api.UniqueIDFilter) Condition {
+	return u.p.cond(func(f *api.PropertyFilter) { f.UniqueID = uf })
+}
+
+func (u UniqueID) Equals(n int) Condition       { return u.wrap(&api.UniqueIDFilter{Equals: &n}) }
+func (u UniqueID) DoesNotEqual(n int) Condition { return u.wrap(&api.UniqueIDFilter{DoesNotEqual: &n}) }
+func (u UniqueID) GreaterThan(n int) Condition  { return u.wrap(&api.UniqueIDFilter{GreaterThan: &n}) }
+func (u UniqueID) LessThan(n int) Condition     { return u.wrap(&api.UniqueIDFilter{LessThan: &n}) }
+
+func (u UniqueID) GreaterThanOrEqualTo(n int) Condition {
+	return u.wrap(&api.UniqueIDFilter{GreaterThanOrEqualTo: &n})
+}
+
+func (u UniqueID) LessThanOrEqualTo(n int) Condition {
+	return u.wrap(&api.UniqueIDFilter{LessThanOrEqualTo: &n})
+}
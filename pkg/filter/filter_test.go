@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func marshal(t *testing.T, v interface{}) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	return string(b)
+}
+
+func TestConditions_JSON(t *testing.T) {
+	day := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	instant := time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		cond Condition
+		want string
+	}{
+		{"title", Prop("Name").Title().Contains("go"), `{"property":"Name","title":{"contains":"go"}}`},
+		{"rich text", Prop("Notes").RichText().IsEmpty(), `{"property":"Notes","rich_text":{"is_empty":true}}`},
+		{"url", Prop("Link").URL().StartsWith("https"), `{"property":"Link","url":{"starts_with":"https"}}`},
+		{"email", Prop("Mail").Email().EndsWith("@x.com"), `{"property":"Mail","email":{"ends_with":"@x.com"}}`},
+		{"phone", Prop("Phone").PhoneNumber().Equals("1"), `{"property":"Phone","phone_number":{"equals":"1"}}`},
+		{"number", Prop("Score").Number().GreaterThan(2.5), `{"property":"Score","number":{"greater_than":2.5}}`},
+		{"number zero", Prop("Score").Number().Equals(0), `{"property":"Score","number":{"equals":0}}`},
+		{"checkbox true", Prop("Done").Checkbox().IsTrue(), `{"property":"Done","checkbox":{"equals":true}}`},
+		{"checkbox false", Prop("Done").Checkbox().IsFalse(), `{"property":"Done","checkbox":{"equals":false}}`},
+		{"select", Prop("Priority").Select().DoesNotEqual("P2"), `{"property":"Priority","select":{"does_not_equal":"P2"}}`},
+		{"status", Prop("Status").Status().Equals("Done"), `{"property":"Status","status":{"equals":"Done"}}`},
+		{"multi select", Prop("Tags").MultiSelect().Contains("a"), `{"property":"Tags","multi_select":{"contains":"a"}}`},
+		{"date", Prop("Due").Date().Before(day), `{"property":"Due","date":{"before":"2026-11-01"}}`},
+		{"date time", Prop("Due").Date().OnOrAfter(instant), `{"property":"Due","date":{"on_or_after":"2026-11-01T09:30:00.000Z"}}`},
+		{"relative date", Prop("Due").Date().PastWeek(), `{"property":"Due","date":{"past_week":{}}}`},
+		{"relation", Prop("Project").Relation().Contains("page-1"), `{"property":"Project","relation":{"contains":"page-1"}}`},
+		{"people", Prop("Owner").People().IsNotEmpty(), `{"property":"Owner","people":{"is_not_empty":true}}`},
+		{"created by", Prop("Author").CreatedBy().DoesNotContain("u1"), `{"property":"Author","created_by":{"does_not_contain":"u1"}}`},
+		{"files", Prop("Files").Files().IsEmpty(), `{"property":"Files","files":{"is_empty":true}}`},
+		{"formula", Prop("Total").Formula().Number().LessThanOrEqualTo(3), `{"property":"Total","formula":{"number":{"less_than_or_equal_to":3}}}`},
+		{"formula text", Prop("Label").Formula().Text().Contains("x"), `{"property":"Label","formula":{"string":{"contains":"x"}}}`},
+		{"rollup any", Prop("Tags").Rollup().Any(Prop("").RichText().Contains("x")), `{"property":"Tags","rollup":{"any":{"rich_text":{"contains":"x"}}}}`},
+		{"rollup date", Prop("Last").Rollup().Date().NextMonth(), `{"property":"Last","rollup":{"date":{"next_month":{}}}}`},
+		{"unique id", Prop("ID").UniqueID().GreaterThan(10), `{"property":"ID","unique_id":{"greater_than":10}}`},
+		{"verification", Prop("Verified").Verification(api.VerificationStatusExpired), `{"property":"Verified","verification":{"status":"expired"}}`},
+		{"created time", CreatedTime().PastMonth(), `{"timestamp":"created_time","created_time":{"past_month":{}}}`},
+		{"last edited time", LastEditedTime().After(day), `{"timestamp":"last_edited_time","last_edited_time":{"after":"2026-11-01"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.cond.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			if got := marshal(t, f); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompound(t *testing.T) {
+	done := Prop("Done").Checkbox().IsTrue()
+	due := Prop("Due").Date().PastWeek()
+	high := Prop("Priority").Select().In("P0", "P1")
+
+	f, err := done.And(due).And(high).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	want := `{"and":[` +
+		`{"property":"Done","checkbox":{"equals":true}},` +
+		`{"property":"Due","date":{"past_week":{}}},` +
+		`{"or":[{"property":"Priority","select":{"equals":"P0"}},{"property":"Priority","select":{"equals":"P1"}}]}` +
+		`]}`
+	if got := marshal(t, f); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	f, err = Or(done, due).Or(Or(high)).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(f.Or) != 4 {
+		t.Errorf("expected nested ors to be flattened into 4 conditions, got %d", len(f.Or))
+	}
+}
+
+func TestCompound_TooDeep(t *testing.T) {
+	a := Prop("A").Checkbox().IsTrue()
+	b := Prop("B").Checkbox().IsTrue()
+	c := Prop("C").Checkbox().IsTrue()
+
+	_, err := And(a, Or(b, And(c, Or(a, b)))).Build()
+	if err == nil {
+		t.Fatal("expected error for filter nested three levels deep")
+	}
+}
+
+func TestBuild_Invalid(t *testing.T) {
+	if _, err := (Condition{}).Build(); err == nil {
+		t.Error("expected error for zero condition")
+	}
+	if _, err := And().Build(); err == nil {
+		t.Error("expected error for empty and")
+	}
+}
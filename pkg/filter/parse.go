@@ -0,0 +1,866 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// SyntaxError is returned when a query cannot be parsed. Line and Col are 1-based and point at the
+// offending token.
+type SyntaxError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Parse compiles a text filter into an api.Filter, e.g.
+//
+//	Status = "In Progress" AND (Priority IN [P0, P1] OR Due < 2026-11-01)
+//
+// The schema of the data source is used to pick the condition matching the type of each
+// property, so that the same "=" becomes a select, status or number condition as appropriate.
+//
+// Conditions are combined with AND and OR, AND binding tighter, and grouped with parentheses.
+// Property names are bare words or, if they contain spaces or are keywords, quoted in backticks.
+// Values are bare words, numbers, true/false or double quoted strings. The operators are:
+//
+//	= != < <= > >=
+//	CONTAINS, NOT CONTAINS, STARTS WITH, ENDS WITH
+//	IN [a, b], NOT IN [a, b]
+//	IS EMPTY, IS NOT EMPTY
+//	IN PAST WEEK|MONTH|YEAR, IN THIS WEEK, IN NEXT WEEK|MONTH|YEAR
+//
+// IN and NOT IN with several values become an "or" of "=" and an "and" of "!=", which counts as a
+// level of nesting: like compound filters, conditions can only be nested two levels deep, e.g. an
+// AND of ORs. Select, multi-select and status values must be options of their property.
+//
+// The names created_time and last_edited_time refer to the creation and last edit time of the
+// pages, unless the data source has properties with those names. Keywords are case-insensitive.
+func Parse(src string, schema map[string]api.Property) (*api.Filter, error) {
+	q, err := ParseQuery(src, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.Filter == nil {
+		return nil, &SyntaxError{Line: 1, Col: 1, Msg: "empty filter"}
+	}
+
+	if len(q.Sorts) > 0 {
+		return nil, &SyntaxError{Line: 1, Col: 1, Msg: "ORDER BY is not allowed in a filter"}
+	}
+
+	return q.Filter, nil
+}
+
+// ParseQuery compiles a text query into an api.QueryDB. The query is a filter, as accepted by
+// Parse, optionally followed by sorts:
+//
+//	Done = false ORDER BY Priority ASC, `Due date` DESC
+//
+// Either part can be left out.
+func ParseQuery(src string, schema map[string]api.Property) (*api.QueryDB, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks, schema: schema}
+	q := NewQuery().Schema(schema)
+
+	if !p.peekKeyword("ORDER") && p.peek().kind != tokEOF {
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		q.Where(c)
+	}
+
+	if p.acceptKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+
+		for {
+			s, err := p.parseSort()
+			if err != nil {
+				return nil, err
+			}
+
+			q.OrderBy(s)
+
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, t.errorf("unexpected %s", t)
+	}
+
+	ret, err := q.Build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	return ret, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokName
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	col  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(t.text)
+	case tokName:
+		return "`" + t.text + "`"
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t token) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Line: t.line, Col: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// isKeyword reports whether the token is the given keyword.
+func (t token) isKeyword(kw string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, kw)
+}
+
+var keywords = []string{"AND", "OR", "NOT", "IN", "IS", "EMPTY", "CONTAINS", "STARTS", "ENDS", "WITH", "ORDER", "BY"}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+
+	rs := []rune(src)
+	line, col := 1, 1
+
+	advance := func() rune {
+		r := rs[0]
+		rs = rs[1:]
+		if r == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+		return r
+	}
+
+	for len(rs) > 0 {
+		r := rs[0]
+		start := token{line: line, col: col}
+
+		switch {
+		case unicode.IsSpace(r):
+			advance()
+			continue
+		case strings.ContainsRune("()[],", r):
+			advance()
+			start.kind = map[rune]tokenKind{'(': tokLParen, ')': tokRParen, '[': tokLBracket, ']': tokRBracket, ',': tokComma}[r]
+			start.text = string(r)
+		case strings.ContainsRune("=!<>", r):
+			op := string(advance())
+			if len(rs) > 0 && rs[0] == '=' && op != "=" {
+				op += string(advance())
+			}
+			if op == "!" {
+				return nil, start.errorf("unexpected \"!\", did you mean \"!=\"?")
+			}
+			start.kind, start.text = tokOp, op
+		case r == '"' || r == '`':
+			advance()
+			var sb strings.Builder
+			closed := false
+			for len(rs) > 0 {
+				c := advance()
+				if c == r {
+					closed = true
+					break
+				}
+				if c == '\\' && r == '"' && len(rs) > 0 {
+					c = advance()
+				}
+				sb.WriteRune(c)
+			}
+			if !closed {
+				return nil, start.errorf("unterminated %s", map[rune]string{'"': "string", '`': "property name"}[r])
+			}
+			start.kind, start.text = tokString, sb.String()
+			if r == '`' {
+				start.kind = tokName
+			}
+		default:
+			var sb strings.Builder
+			for len(rs) > 0 && !unicode.IsSpace(rs[0]) && !strings.ContainsRune("()[],=!<>\"`", rs[0]) {
+				sb.WriteRune(advance())
+			}
+			start.kind, start.text = tokWord, sb.String()
+		}
+
+		toks = append(toks, start)
+	}
+
+	return append(toks, token{kind: tokEOF, line: line, col: col}), nil
+}
+
+type parser struct {
+	toks   []token
+	pos    int
+	schema map[string]api.Property
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peekKeyword(kw string) bool {
+	return p.peek().isKeyword(kw)
+}
+
+func (p *parser) acceptKeyword(kw string) bool {
+	if p.peekKeyword(kw) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if t := p.next(); !t.isKeyword(kw) {
+		return t.errorf("expected %s, got %s", kw, t)
+	}
+	return nil
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, t.errorf("expected %s, got %s", what, t)
+	}
+	return t, nil
+}
+
+func (p *parser) parseOr() (Condition, error) {
+	var conds []Condition
+	var starts []token
+	for {
+		starts = append(starts, p.peek())
+		c, err := p.parseAnd()
+		if err != nil {
+			return Condition{}, err
+		}
+		conds = append(conds, c)
+
+		if !p.acceptKeyword("OR") {
+			break
+		}
+	}
+
+	return combine(conds, starts, Or)
+}
+
+func (p *parser) parseAnd() (Condition, error) {
+	var conds []Condition
+	var starts []token
+	for {
+		starts = append(starts, p.peek())
+		c, err := p.parsePrimary()
+		if err != nil {
+			return Condition{}, err
+		}
+		conds = append(conds, c)
+
+		if !p.acceptKeyword("AND") {
+			break
+		}
+	}
+
+	return combine(conds, starts, And)
+}
+
+// maxDepth is how deep conditions can be nested, see api.PropertyFilter.Validate.
+const maxDepth = 2
+
+// combine joins the conditions, which start at the given tokens, with And or Or. It returns an
+// error at the first condition nested too deep once joined.
+func combine(conds []Condition, starts []token, join func(...Condition) Condition) (Condition, error) {
+	if len(conds) == 1 {
+		return conds[0], nil
+	}
+
+	ret := join(conds...)
+	for i, c := range conds {
+		// Conditions joined the same way are flattened into ret, the others are nested in it.
+		d := depth(c.f)
+		if (len(ret.f.Or) == 0 || len(c.f.Or) == 0) && (len(ret.f.And) == 0 || len(c.f.And) == 0) {
+			d++
+		}
+		if d > maxDepth {
+			return Condition{}, starts[i].errorf("conditions can only be nested %d levels deep, e.g. an AND of ORs", maxDepth)
+		}
+	}
+
+	return ret, nil
+}
+
+// depth returns how many levels of compound filters f has.
+func depth(f api.PropertyFilter) int {
+	ret := 0
+	for _, c := range append(append([]api.PropertyFilter{}, f.And...), f.Or...) {
+		if d := depth(c) + 1; d > ret {
+			ret = d
+		}
+	}
+
+	return ret
+}
+
+func (p *parser) parsePrimary() (Condition, error) {
+	if p.peek().kind == tokLParen {
+		p.next()
+
+		c, err := p.parseOr()
+		if err != nil {
+			return Condition{}, err
+		}
+
+		if _, err := p.expect(tokRParen, "\")\""); err != nil {
+			return Condition{}, err
+		}
+
+		return c, nil
+	}
+
+	return p.parseComparison()
+}
+
+// property is the target of a comparison: a property of the schema, or a page timestamp.
+type property struct {
+	tok       token
+	name      string
+	typ       string
+	timestamp bool
+
+	// The property of the schema, unset for timestamps.
+	schema api.Property
+}
+
+func (p *parser) parseProperty() (property, error) {
+	t := p.next()
+	if t.kind != tokName && (t.kind != tokWord || isKeyword(t.text)) {
+		return property{}, t.errorf("expected a property name, got %s", t)
+	}
+
+	if prop, ok := lookup(p.schema, t.text); ok {
+		return property{tok: t, name: t.text, typ: prop.Type, schema: prop}, nil
+	}
+
+	if t.text == string(api.FilterTimestampCreatedTime) || t.text == string(api.FilterTimestampLastEditedTime) {
+		return property{tok: t, name: t.text, typ: t.text, timestamp: true}, nil
+	}
+
+	return property{}, t.errorf("unknown property %q", t.text)
+}
+
+func isKeyword(s string) bool {
+	for _, kw := range keywords {
+		if strings.EqualFold(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// operator is a parsed comparison operator, e.g. "=", "NOT CONTAINS" or "IS EMPTY".
+type operator struct {
+	tok  token
+	name string
+}
+
+func (p *parser) parseOperator() (operator, error) {
+	t := p.next()
+	op := operator{tok: t}
+
+	switch {
+	case t.kind == tokOp:
+		op.name = t.text
+	case t.isKeyword("CONTAINS"):
+		op.name = "CONTAINS"
+	case t.isKeyword("STARTS"), t.isKeyword("ENDS"):
+		if err := p.expectKeyword("WITH"); err != nil {
+			return op, err
+		}
+		op.name = strings.ToUpper(t.text) + " WITH"
+	case t.isKeyword("IN"):
+		op.name = "IN"
+		if w := p.peek(); w.kind == tokWord {
+			switch strings.ToUpper(w.text) {
+			case "PAST", "THIS", "NEXT":
+				p.next()
+				unit := p.next()
+				op.name = "IN " + strings.ToUpper(w.text) + " " + strings.ToUpper(unit.text)
+				if !relativeDates[op.name] {
+					return op, unit.errorf("expected a relative date like PAST WEEK, got %s %s", w, unit)
+				}
+			}
+		}
+	case t.isKeyword("NOT"):
+		n := p.next()
+		switch {
+		case n.isKeyword("CONTAINS"):
+			op.name = "NOT CONTAINS"
+		case n.isKeyword("IN"):
+			op.name = "NOT IN"
+		default:
+			return op, n.errorf("expected CONTAINS or IN after NOT, got %s", n)
+		}
+	case t.isKeyword("IS"):
+		op.name = "IS EMPTY"
+		if p.acceptKeyword("NOT") {
+			op.name = "IS NOT EMPTY"
+		}
+		if err := p.expectKeyword("EMPTY"); err != nil {
+			return op, err
+		}
+	default:
+		return op, t.errorf("expected an operator, got %s", t)
+	}
+
+	return op, nil
+}
+
+var relativeDates = map[string]bool{
+	"IN PAST WEEK": true, "IN PAST MONTH": true, "IN PAST YEAR": true, "IN THIS WEEK": true,
+	"IN NEXT WEEK": true, "IN NEXT MONTH": true, "IN NEXT YEAR": true,
+}
+
+// parseValues parses the value of the operator: nothing, a list for IN and NOT IN, or a single
+// value otherwise.
+func (p *parser) parseValues(op operator) ([]token, error) {
+	if strings.HasPrefix(op.name, "IS") || relativeDates[op.name] {
+		return nil, nil
+	}
+
+	if op.name != "IN" && op.name != "NOT IN" {
+		v, err := p.parseValue()
+		return []token{v}, err
+	}
+
+	if _, err := p.expect(tokLBracket, "\"[\""); err != nil {
+		return nil, err
+	}
+
+	var vs []token
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		vs = append(vs, v)
+
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+
+	if _, err := p.expect(tokRBracket, "\"]\""); err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+func (p *parser) parseValue() (token, error) {
+	t := p.next()
+	if t.kind != tokString && t.kind != tokWord {
+		return t, t.errorf("expected a value, got %s", t)
+	}
+	return t, nil
+}
+
+func (p *parser) parseComparison() (Condition, error) {
+	prop, err := p.parseProperty()
+	if err != nil {
+		return Condition{}, err
+	}
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return Condition{}, err
+	}
+
+	vals, err := p.parseValues(op)
+	if err != nil {
+		return Condition{}, err
+	}
+
+	// IN and NOT IN are expanded into an "or" of equals, and an "and" of does not equal. A single
+	// value is a single condition, so that it does not add a level of nesting.
+	if op.name == "IN" || op.name == "NOT IN" {
+		eq, combine := "=", Or
+		if op.name == "NOT IN" {
+			eq, combine = "!=", And
+		}
+
+		var conds []Condition
+		for _, v := range vals {
+			c, err := p.condition(prop, operator{tok: op.tok, name: eq}, v)
+			if err != nil {
+				return Condition{}, err
+			}
+			conds = append(conds, c)
+		}
+
+		if len(conds) == 1 {
+			return conds[0], nil
+		}
+		return combine(conds...), nil
+	}
+
+	var v token
+	if len(vals) > 0 {
+		v = vals[0]
+	}
+
+	return p.condition(prop, op, v)
+}
+
+// condition returns the condition for the comparison, picked by the type of the property.
+func (p *parser) condition(prop property, op operator, v token) (Condition, error) {
+	unsupported := func() (Condition, error) {
+		return Condition{}, op.tok.errorf("operator %s is not supported for %s property %q", op.name, prop.typ, prop.name)
+	}
+
+	pr := Prop(prop.name)
+
+	switch prop.typ {
+	case "title", "rich_text", "url", "email", "phone_number":
+		t := map[string]func() Text{
+			"title": pr.Title, "rich_text": pr.RichText, "url": pr.URL, "email": pr.Email, "phone_number": pr.PhoneNumber,
+		}[prop.typ]()
+		switch op.name {
+		case "=":
+			return t.Equals(v.text), nil
+		case "!=":
+			return t.DoesNotEqual(v.text), nil
+		case "CONTAINS":
+			return t.Contains(v.text), nil
+		case "NOT CONTAINS":
+			return t.DoesNotContain(v.text), nil
+		case "STARTS WITH":
+			return t.StartsWith(v.text), nil
+		case "ENDS WITH":
+			return t.EndsWith(v.text), nil
+		case "IS EMPTY":
+			return t.IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return t.IsNotEmpty(), nil
+		}
+	case "number":
+		n := pr.Number()
+		switch op.name {
+		case "IS EMPTY":
+			return n.IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return n.IsNotEmpty(), nil
+		case "CONTAINS", "NOT CONTAINS", "STARTS WITH", "ENDS WITH":
+			return unsupported()
+		}
+		if relativeDates[op.name] {
+			return unsupported()
+		}
+		f, err := parseNumber(v)
+		if err != nil {
+			return Condition{}, err
+		}
+		switch op.name {
+		case "=":
+			return n.Equals(f), nil
+		case "!=":
+			return n.DoesNotEqual(f), nil
+		case "<":
+			return n.LessThan(f), nil
+		case "<=":
+			return n.LessThanOrEqualTo(f), nil
+		case ">":
+			return n.GreaterThan(f), nil
+		case ">=":
+			return n.GreaterThanOrEqualTo(f), nil
+		}
+	case "checkbox":
+		if op.name != "=" && op.name != "!=" {
+			return unsupported()
+		}
+		b, err := strconv.ParseBool(strings.ToLower(v.text))
+		if err != nil || v.kind != tokWord {
+			return Condition{}, v.errorf("expected true or false, got %s", v)
+		}
+		if b == (op.name == "=") {
+			return pr.Checkbox().IsTrue(), nil
+		}
+		return pr.Checkbox().IsFalse(), nil
+	case "select", "status":
+		var s interface {
+			Equals(string) Condition
+			DoesNotEqual(string) Condition
+			IsEmpty() Condition
+			IsNotEmpty() Condition
+		} = pr.Select()
+		if prop.typ == "status" {
+			s = pr.Status()
+		}
+		if op.name == "=" || op.name == "!=" {
+			if err := checkOption(prop, v); err != nil {
+				return Condition{}, err
+			}
+		}
+		switch op.name {
+		case "=":
+			return s.Equals(v.text), nil
+		case "!=":
+			return s.DoesNotEqual(v.text), nil
+		case "IS EMPTY":
+			return s.IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return s.IsNotEmpty(), nil
+		}
+	case "multi_select":
+		m := pr.MultiSelect()
+		switch op.name {
+		case "=", "CONTAINS", "!=", "NOT CONTAINS":
+			if err := checkOption(prop, v); err != nil {
+				return Condition{}, err
+			}
+		}
+		switch op.name {
+		case "=", "CONTAINS":
+			return m.Contains(v.text), nil
+		case "!=", "NOT CONTAINS":
+			return m.DoesNotContain(v.text), nil
+		case "IS EMPTY":
+			return m.IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return m.IsNotEmpty(), nil
+		}
+	case "date", "created_time", "last_edited_time":
+		var d Date
+		switch {
+		case prop.timestamp && prop.typ == "created_time":
+			d = CreatedTime()
+		case prop.timestamp:
+			d = LastEditedTime()
+		case prop.typ == "created_time":
+			d = pr.CreatedTime()
+		case prop.typ == "last_edited_time":
+			d = pr.LastEditedTime()
+		default:
+			d = pr.Date()
+		}
+		return p.dateCondition(d, op, v, unsupported)
+	case "people", "relation", "created_by", "last_edited_by":
+		c := map[string]func() Contains{
+			"people": pr.People, "relation": pr.Relation, "created_by": pr.CreatedBy, "last_edited_by": pr.LastEditedBy,
+		}[prop.typ]()
+		switch op.name {
+		case "=", "CONTAINS":
+			return c.Contains(v.text), nil
+		case "!=", "NOT CONTAINS":
+			return c.DoesNotContain(v.text), nil
+		case "IS EMPTY":
+			return c.IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return c.IsNotEmpty(), nil
+		}
+	case "files":
+		switch op.name {
+		case "IS EMPTY":
+			return pr.Files().IsEmpty(), nil
+		case "IS NOT EMPTY":
+			return pr.Files().IsNotEmpty(), nil
+		}
+	case "unique_id":
+		if strings.HasPrefix(op.name, "IS") || strings.Contains(op.name, "CONTAINS") || strings.HasSuffix(op.name, "WITH") ||
+			relativeDates[op.name] {
+			return unsupported()
+		}
+		// Accept both the number and the full ID, e.g. 12 and "TASK-12".
+		num := v.text
+		if i := strings.LastIndex(num, "-"); i > 0 {
+			if _, err := strconv.Atoi(num[:i]); err != nil {
+				num = num[i+1:]
+			}
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return Condition{}, v.errorf("expected a unique ID, got %s", v)
+		}
+		u := pr.UniqueID()
+		switch op.name {
+		case "=":
+			return u.Equals(n), nil
+		case "!=":
+			return u.DoesNotEqual(n), nil
+		case "<":
+			return u.LessThan(n), nil
+		case "<=":
+			return u.LessThanOrEqualTo(n), nil
+		case ">":
+			return u.GreaterThan(n), nil
+		case ">=":
+			return u.GreaterThanOrEqualTo(n), nil
+		}
+	case "verification":
+		if op.name == "=" {
+			return pr.Verification(api.VerificationStatus(strings.ToLower(v.text))), nil
+		}
+	default:
+		return Condition{}, prop.tok.errorf("%s property %q cannot be filtered in a text query", prop.typ, prop.name)
+	}
+
+	return unsupported()
+}
+
+// checkOption returns an error at the value if it is not an option of the select, multi-select or
+// status property.
+func checkOption(prop property, v token) error {
+	var opts []string
+	switch prop.typ {
+	case "select":
+		opts = selectOptions(prop.schema.Select)
+	case "multi_select":
+		opts = selectOptions(prop.schema.MultiSelect)
+	case "status":
+		if prop.schema.Status != nil {
+			for _, o := range prop.schema.Status.Options {
+				opts = append(opts, o.Name)
+			}
+		}
+	}
+
+	for _, o := range opts {
+		if o == v.text {
+			return nil
+		}
+	}
+
+	return v.errorf("%q is not an option of property %q", v.text, prop.name)
+}
+
+func (p *parser) dateCondition(d Date, op operator, v token, unsupported func() (Condition, error)) (Condition, error) {
+	switch op.name {
+	case "IS EMPTY":
+		return d.IsEmpty(), nil
+	case "IS NOT EMPTY":
+		return d.IsNotEmpty(), nil
+	case "IN PAST WEEK":
+		return d.PastWeek(), nil
+	case "IN PAST MONTH":
+		return d.PastMonth(), nil
+	case "IN PAST YEAR":
+		return d.PastYear(), nil
+	case "IN THIS WEEK":
+		return d.ThisWeek(), nil
+	case "IN NEXT WEEK":
+		return d.NextWeek(), nil
+	case "IN NEXT MONTH":
+		return d.NextMonth(), nil
+	case "IN NEXT YEAR":
+		return d.NextYear(), nil
+	}
+
+	t, _, err := api.ParseDate(v.text, "")
+	if err != nil {
+		return Condition{}, v.errorf("expected a date like 2006-01-02 or 2006-01-02T15:04:05Z, got %s", v)
+	}
+
+	switch op.name {
+	case "=":
+		return d.Equals(t), nil
+	case "<":
+		return d.Before(t), nil
+	case "<=":
+		return d.OnOrBefore(t), nil
+	case ">":
+		return d.After(t), nil
+	case ">=":
+		return d.OnOrAfter(t), nil
+	}
+
+	return unsupported()
+}
+
+func parseNumber(v token) (float64, error) {
+	f, err := strconv.ParseFloat(v.text, 64)
+	if err != nil || v.kind != tokWord {
+		return 0, v.errorf("expected a number, got %s", v)
+	}
+	return f, nil
+}
+
+func (p *parser) parseSort() (api.Sort, error) {
+	prop, err := p.parseProperty()
+	if err != nil {
+		return api.Sort{}, err
+	}
+
+	desc := false
+	switch {
+	case p.acceptKeyword("ASC"):
+	case p.acceptKeyword("DESC"):
+		desc = true
+	}
+
+	switch {
+	case prop.timestamp && desc:
+		return DescTimestamp(api.SortTimestamp(prop.name)), nil
+	case prop.timestamp:
+		return AscTimestamp(api.SortTimestamp(prop.name)), nil
+	case desc:
+		return Desc(prop.name), nil
+	}
+
+	return Asc(prop.name), nil
+}
@@ -0,0 +1,208 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+var parseSchema = map[string]api.Property{
+	"Name":     {Type: "title"},
+	"Status":   {Type: "status", Status: &api.Status{Options: []api.StatusOption{{Name: "In Progress"}, {Name: "Done"}}}},
+	"Priority": {Type: "select", Select: &api.Select{Options: []api.Option{{Name: "P0"}, {Name: "P1"}, {Name: "P2"}}}},
+	"Tags":     {Type: "multi_select", MultiSelect: &api.Select{Options: []api.Option{{Name: "go"}, {Name: "rust"}}}},
+	"Due":      {Type: "date"},
+	"Due date": {Type: "date"},
+	"Score":    {Type: "number"},
+	"Done":     {Type: "checkbox"},
+	"Owner":    {Type: "people"},
+	"Files":    {Type: "files"},
+	"ID":       {Type: "unique_id"},
+	"Total":    {Type: "formula"},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "example",
+			src:  `Status = "In Progress" AND (Priority in [P0,P1] OR Due < 2026-11-01)`,
+			want: `{"and":[` +
+				`{"property":"Status","status":{"equals":"In Progress"}},` +
+				`{"or":[` +
+				`{"property":"Priority","select":{"equals":"P0"}},` +
+				`{"property":"Priority","select":{"equals":"P1"}},` +
+				`{"property":"Due","date":{"before":"2026-11-01"}}` +
+				`]}]}`,
+		},
+		{
+			name: "and binds tighter than or",
+			src:  `Done = true OR Score > 2.5 AND Score <= 10`,
+			want: `{"or":[` +
+				`{"property":"Done","checkbox":{"equals":true}},` +
+				`{"and":[{"property":"Score","number":{"greater_than":2.5}},{"property":"Score","number":{"less_than_or_equal_to":10}}]}` +
+				`]}`,
+		},
+		{
+			name: "text operators",
+			src:  "Name contains \"a \\\"b\\\"\" and Name NOT CONTAINS x AND Name starts with Go and Name ENDS WITH s",
+			want: `{"and":[` +
+				`{"property":"Name","title":{"contains":"a \"b\""}},` +
+				`{"property":"Name","title":{"does_not_contain":"x"}},` +
+				`{"property":"Name","title":{"starts_with":"Go"}},` +
+				`{"property":"Name","title":{"ends_with":"s"}}` +
+				`]}`,
+		},
+		{
+			name: "multi select",
+			src:  `Tags NOT IN [go, rust]`,
+			want: `{"and":[{"property":"Tags","multi_select":{"does_not_contain":"go"}},{"property":"Tags","multi_select":{"does_not_contain":"rust"}}]}`,
+		},
+		{
+			name: "empty checks",
+			src:  "Owner IS EMPTY OR Files is not empty OR `Due date` IS NOT EMPTY",
+			want: `{"or":[` +
+				`{"property":"Owner","people":{"is_empty":true}},` +
+				`{"property":"Files","files":{"is_not_empty":true}},` +
+				`{"property":"Due date","date":{"is_not_empty":true}}` +
+				`]}`,
+		},
+		{
+			name: "relative dates and timestamps",
+			src:  `Due IN NEXT WEEK AND created_time in past month AND last_edited_time >= 2026-01-02T10:00:00Z`,
+			want: `{"and":[` +
+				`{"property":"Due","date":{"next_week":{}}},` +
+				`{"timestamp":"created_time","created_time":{"past_month":{}}},` +
+				`{"timestamp":"last_edited_time","last_edited_time":{"on_or_after":"2026-01-02T10:00:00.000Z"}}` +
+				`]}`,
+		},
+		{
+			name: "unique id",
+			src:  `ID > TASK-12`,
+			want: `{"property":"ID","unique_id":{"greater_than":12}}`,
+		},
+		{
+			name: "unique id number",
+			src:  `ID = 12`,
+			want: `{"property":"ID","unique_id":{"equals":12}}`,
+		},
+		{
+			name: "negative unique id",
+			src:  `ID = -5`,
+			want: `{"property":"ID","unique_id":{"equals":-5}}`,
+		},
+		{
+			name: "in a single value",
+			src:  `Score = 3 OR (Due < 2026-11-01 AND Priority IN [P0])`,
+			want: `{"or":[` +
+				`{"property":"Score","number":{"equals":3}},` +
+				`{"and":[{"property":"Due","date":{"before":"2026-11-01"}},{"property":"Priority","select":{"equals":"P0"}}]}` +
+				`]}`,
+		},
+		{
+			name: "checkbox not equal",
+			src:  `Done != TRUE`,
+			want: `{"property":"Done","checkbox":{"equals":false}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse(tt.src, parseSchema)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if got := marshal(t, f); got != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("Done = false\nORDER BY Priority, `Due date` DESC, created_time asc", parseSchema)
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+
+	want := `{"sorts":[` +
+		`{"property":"Priority","direction":"ascending"},` +
+		`{"property":"Due date","direction":"descending"},` +
+		`{"direction":"ascending","timestamp":"created_time"}` +
+		`],"filter":{"property":"Done","checkbox":{"equals":false}}}`
+	if got := marshal(t, q); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	q, err = ParseQuery("order by Score desc", parseSchema)
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	if q.Filter != nil || len(q.Sorts) != 1 {
+		t.Errorf("unexpected query: %+v", q)
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		src       string
+		line, col int
+		msg       string
+	}{
+		{`Status = "In Progress`, 1, 10, "unterminated string"},
+		{"Done = true AND\n  Nope = 1", 2, 3, `unknown property "Nope"`},
+		{`(Done = true`, 1, 13, `expected ")", got end of input`},
+		{`Score > abc`, 1, 9, `expected a number, got "abc"`},
+		{`Score contains 1`, 1, 7, "operator CONTAINS is not supported for number property \"Score\""},
+		{`Due < tomorrow`, 1, 7, `expected a date like 2006-01-02 or 2006-01-02T15:04:05Z, got "tomorrow"`},
+		{`Due IN PAST DECADE`, 1, 13, "expected a relative date like PAST WEEK"},
+		{`Priority IN P0`, 1, 13, `expected "[", got "P0"`},
+		{`Done ! true`, 1, 6, `unexpected "!"`},
+		{`Done = true Score = 1`, 1, 13, `unexpected "Score"`},
+		{`Total = 1`, 1, 1, `formula property "Total" cannot be filtered in a text query`},
+		{`Done = maybe`, 1, 8, "expected true or false"},
+		{`AND = 1`, 1, 1, `expected a property name, got "AND"`},
+		{`Done = true ORDER Name`, 1, 19, `expected BY, got "Name"`},
+		{`Score IN PAST WEEK`, 1, 7, `operator IN PAST WEEK is not supported for number property "Score"`},
+		{`ID IN PAST WEEK`, 1, 4, `operator IN PAST WEEK is not supported for unique_id property "ID"`},
+		{`Priority = P9`, 1, 12, `"P9" is not an option of property "Priority"`},
+		{`Tags IN [go, java]`, 1, 14, `"java" is not an option of property "Tags"`},
+		{`Status != Blocked`, 1, 11, `"Blocked" is not an option of property "Status"`},
+		{
+			"Score = 3 OR\n(Due < 2026-11-01 AND Priority IN [P0, P1])", 2, 1,
+			"conditions can only be nested 2 levels deep",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src, parseSchema)
+
+			var se *SyntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("expected a SyntaxError, got %v", err)
+			}
+			if se.Line != tt.line || se.Col != tt.col {
+				t.Errorf("expected position %d:%d, got %d:%d (%v)", tt.line, tt.col, se.Line, se.Col, se)
+			}
+			if !strings.Contains(se.Msg, tt.msg) {
+				t.Errorf("expected message containing %q, got %q", tt.msg, se.Msg)
+			}
+		})
+	}
+}
+
+func TestParse_SchemaErrors(t *testing.T) {
+	if _, err := Parse(`Priority = P9`, parseSchema); err == nil {
+		t.Error("expected error for unknown option")
+	}
+	if _, err := Parse(`Done = true ORDER BY Name`, parseSchema); err == nil {
+		t.Error("expected error for sorts in a filter")
+	}
+	if _, err := Parse(``, parseSchema); err == nil {
+		t.Error("expected error for empty filter")
+	}
+}
@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// Asc sorts by the property in ascending order.
+func Asc(property string) api.Sort {
+	return api.Sort{Property: property, Direction: api.Ptr(api.SortDirectionAscending)}
+}
+
+// Desc sorts by the property in descending order.
+func Desc(property string) api.Sort {
+	return api.Sort{Property: property, Direction: api.Ptr(api.SortDirectionDescending)}
+}
+
+// AscTimestamp sorts by the creation or last edit time of the pages in ascending order.
+func AscTimestamp(ts api.SortTimestamp) api.Sort {
+	return api.Sort{Timestamp: &ts, Direction: api.Ptr(api.SortDirectionAscending)}
+}
+
+// DescTimestamp sorts by the creation or last edit time of the pages in descending order.
+func DescTimestamp(ts api.SortTimestamp) api.Sort {
+	return api.Sort{Timestamp: &ts, Direction: api.Ptr(api.SortDirectionDescending)}
+}
+
+// Query builds an api.QueryDB.
+type Query struct {
+	cond     *Condition
+	sorts    []api.Sort
+	pageSize int
+	schema   map[string]api.Property
+}
+
+// NewQuery returns an empty query, which returns all the pages of the data source.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Where returns a query for the pages matching the condition.
+func Where(c Condition) *Query {
+	return NewQuery().Where(c)
+}
+
+// Where sets the condition of the query.
+func (q *Query) Where(c Condition) *Query {
+	q.cond = &c
+	return q
+}
+
+// OrderBy adds sorts to the query. Earlier sorts take precedence.
+func (q *Query) OrderBy(sorts ...api.Sort) *Query {
+	q.sorts = append(q.sorts, sorts...)
+	return q
+}
+
+// PageSize sets how many pages are fetched per request, at most 100. Zero leaves it to Notion.
+func (q *Query) PageSize(n int) *Query {
+	q.pageSize = n
+	return q
+}
+
+// Schema sets the properties of the data source, e.g. api.DataSource.Properties. Build then
+// checks that the query only uses existing properties, with conditions matching their types.
+func (q *Query) Schema(schema map[string]api.Property) *Query {
+	q.schema = schema
+	return q
+}
+
+// Build validates the query and returns it.
+func (q *Query) Build() (*api.QueryDB, error) {
+	ret := &api.QueryDB{Sorts: q.sorts, PageSize: q.pageSize}
+
+	if q.pageSize < 0 || q.pageSize > 100 {
+		return nil, fmt.Errorf("page size must be between 0 (the default of Notion) and 100, got %d", q.pageSize)
+	}
+
+	if q.cond != nil {
+		f, err := q.cond.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		ret.Filter = f
+	}
+
+	if q.schema != nil {
+		if err := CheckSchema(ret, q.schema); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,67 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestQuery_Build(t *testing.T) {
+	q, err := Where(Prop("Done").Checkbox().IsFalse()).
+		OrderBy(Asc("Name"), DescTimestamp(api.SortTimestampLastEditedTime)).
+		PageSize(50).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	want := `{"sorts":[` +
+		`{"property":"Name","direction":"ascending"},` +
+		`{"direction":"descending","timestamp":"last_edited_time"}` +
+		`],"filter":{"property":"Done","checkbox":{"equals":false}},"page_size":50}`
+	if got := marshal(t, q); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestQuery_SortsDoNotShareDirections(t *testing.T) {
+	a := Asc("A")
+	*a.Direction = api.SortDirectionDescending
+
+	if b := Asc("B"); *b.Direction != api.SortDirectionAscending {
+		t.Errorf("expected ascending, got %s", *b.Direction)
+	}
+	if api.SortDirectionAscending != "ascending" {
+		t.Errorf("package level direction was modified: %s", api.SortDirectionAscending)
+	}
+}
+
+func TestQuery_NoFilter(t *testing.T) {
+	q, err := NewQuery().OrderBy(Desc("Score")).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if q.Filter != nil {
+		t.Errorf("expected no filter, got %+v", q.Filter)
+	}
+	if len(q.Sorts) != 1 {
+		t.Errorf("expected 1 sort, got %d", len(q.Sorts))
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	if _, err := NewQuery().PageSize(101).Build(); err == nil || err.Error() != "page size must be between 0 (the default of Notion) and 100, got 101" {
+		t.Errorf("unexpected error for page size over 100: %v", err)
+	}
+	if _, err := NewQuery().PageSize(0).Build(); err != nil {
+		t.Errorf("unexpected error for the default page size: %v", err)
+	}
+	if _, err := Where(Prop("Score").Number().Equals(1).And()).Where(Condition{}).Build(); err == nil {
+		t.Error("expected error for invalid condition")
+	}
+
+	schema := map[string]api.Property{"Score": {Type: "number"}}
+	if _, err := Where(Prop("Score").Title().Equals("x")).Schema(schema).Build(); err == nil {
+		t.Error("expected error for condition not matching the schema")
+	}
+}
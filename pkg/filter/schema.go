@@ -0,0 +1,164 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// CheckSchema checks the filter and sorts of the query against the properties of a data source:
+// every property must exist, every condition must match the type of its property and select,
+// multi-select and status values must be existing options. Properties can be referred to by name
+// or by ID.
+func CheckSchema(q *api.QueryDB, schema map[string]api.Property) error {
+	if q.Filter != nil {
+		if err := checkFilter("filter", *q.Filter, schema); err != nil {
+			return err
+		}
+	}
+
+	for i, s := range q.Sorts {
+		if s.Property == "" {
+			continue
+		}
+
+		if _, ok := lookup(schema, s.Property); !ok {
+			return fmt.Errorf("sorts[%d]: unknown property %q", i, s.Property)
+		}
+	}
+
+	return nil
+}
+
+func checkFilter(path string, f api.PropertyFilter, schema map[string]api.Property) error {
+	for i, c := range f.And {
+		if err := checkFilter(fmt.Sprintf("%s.and[%d]", path, i), c, schema); err != nil {
+			return err
+		}
+	}
+
+	for i, c := range f.Or {
+		if err := checkFilter(fmt.Sprintf("%s.or[%d]", path, i), c, schema); err != nil {
+			return err
+		}
+	}
+
+	if f.Property == "" {
+		return nil
+	}
+
+	prop, ok := lookup(schema, f.Property)
+	if !ok {
+		return fmt.Errorf("%s: unknown property %q", path, f.Property)
+	}
+
+	if cond := conditionType(f); cond != "" && cond != prop.Type {
+		return fmt.Errorf("%s: property %q is of type %s, it cannot be filtered with a %s condition",
+			path, f.Property, prop.Type, cond)
+	}
+
+	switch {
+	case f.Select != nil:
+		return checkOptions(path, f.Property, selectOptions(prop.Select), f.Select.Equals, f.Select.DoesNotEqual)
+	case f.MultiSelect != nil:
+		return checkOptions(path, f.Property, selectOptions(prop.MultiSelect),
+			f.MultiSelect.Contains, f.MultiSelect.DoesNotContain)
+	case f.Status != nil:
+		var opts []string
+		if prop.Status != nil {
+			for _, o := range prop.Status.Options {
+				opts = append(opts, o.Name)
+			}
+		}
+
+		return checkOptions(path, f.Property, opts, f.Status.Equals, f.Status.DoesNotEqual)
+	}
+
+	return nil
+}
+
+// lookup finds the property by name, or else by ID.
+func lookup(schema map[string]api.Property, nameOrID string) (api.Property, bool) {
+	if p, ok := schema[nameOrID]; ok {
+		return p, true
+	}
+
+	for _, p := range schema {
+		if p.ID == nameOrID {
+			return p, true
+		}
+	}
+
+	return api.Property{}, false
+}
+
+// conditionType returns the property type the condition of the filter applies to.
+func conditionType(f api.PropertyFilter) string {
+	conds := map[string]bool{
+		"title":            f.Title != nil,
+		"rich_text":        f.RichText != nil,
+		"url":              f.URL != nil,
+		"email":            f.Email != nil,
+		"phone_number":     f.Phone != nil,
+		"number":           f.Number != nil,
+		"checkbox":         f.Checkbox != nil,
+		"select":           f.Select != nil,
+		"multi_select":     f.MultiSelect != nil,
+		"date":             f.Date != nil,
+		"created_time":     f.CreatedTime != nil,
+		"last_edited_time": f.LastEditedTime != nil,
+		"status":           f.Status != nil,
+		"relation":         f.Relation != nil,
+		"people":           f.People != nil,
+		"created_by":       f.CreatedBy != nil,
+		"last_edited_by":   f.LastEditedBy != nil,
+		"files":            f.Files != nil,
+		"formula":          f.Formula != nil,
+		"rollup":           f.Rollup != nil,
+		"unique_id":        f.UniqueID != nil,
+		"verification":     f.Verification != nil,
+	}
+
+	for typ, set := range conds {
+		if set {
+			return typ
+		}
+	}
+
+	return ""
+}
+
+func selectOptions(s *api.Select) []string {
+	if s == nil {
+		return nil
+	}
+
+	var ret []string
+	for _, o := range s.Options {
+		ret = append(ret, o.Name)
+	}
+
+	return ret
+}
+
+func checkOptions(path, property string, options []string, values ...string) error {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+
+		found := false
+		for _, o := range options {
+			if o == v {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s: %q is not an option of property %q", path, v, property)
+		}
+	}
+
+	return nil
+}
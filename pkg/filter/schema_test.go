@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+var testSchema = map[string]api.Property{
+	"Name":     {ID: "title", Type: "title"},
+	"Score":    {ID: "a%3Ab", Type: "number"},
+	"Priority": {ID: "prio", Type: "select", Select: &api.Select{Options: []api.Option{{Name: "P0"}, {Name: "P1"}}}},
+	"Tags":     {ID: "tags", Type: "multi_select", MultiSelect: &api.Select{Options: []api.Option{{Name: "go"}}}},
+	"Status":   {ID: "st", Type: "status", Status: &api.Status{Options: []api.StatusOption{{Name: "Done"}}}},
+	"Created":  {ID: "ct", Type: "created_time"},
+}
+
+func TestCheckSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    Condition
+		sorts   []api.Sort
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cond: And(
+				Prop("Name").Title().Contains("x"),
+				Prop("a%3Ab").Number().GreaterThan(1),
+				Prop("Priority").Select().In("P0", "P1"),
+				Prop("Tags").MultiSelect().Contains("go"),
+				Prop("Status").Status().Equals("Done"),
+				Prop("Created").CreatedTime().PastWeek().Or(CreatedTime().PastWeek()),
+			),
+			sorts: []api.Sort{Asc("Name"), AscTimestamp(api.SortTimestampCreatedTime)},
+		},
+		{
+			name:    "unknown property",
+			cond:    Prop("Nope").Title().Equals("x"),
+			wantErr: `filter: unknown property "Nope"`,
+		},
+		{
+			name:    "wrong type",
+			cond:    Prop("Name").Title().IsNotEmpty().And(Prop("Score").RichText().Equals("1")),
+			wantErr: `filter.and[1]: property "Score" is of type number, it cannot be filtered with a rich_text condition`,
+		},
+		{
+			name:    "date used for created time",
+			cond:    Prop("Created").Date().PastWeek(),
+			wantErr: `property "Created" is of type created_time, it cannot be filtered with a date condition`,
+		},
+		{
+			name:    "select used for status",
+			cond:    Prop("Status").Select().Equals("Done"),
+			wantErr: `property "Status" is of type status, it cannot be filtered with a select condition`,
+		},
+		{
+			name:    "unknown select option",
+			cond:    Prop("Priority").Select().In("P0", "P9"),
+			wantErr: `filter.or[1]: "P9" is not an option of property "Priority"`,
+		},
+		{
+			name:    "unknown multi select option",
+			cond:    Prop("Tags").MultiSelect().DoesNotContain("rust"),
+			wantErr: `"rust" is not an option of property "Tags"`,
+		},
+		{
+			name:    "unknown status option",
+			cond:    Prop("Status").Status().DoesNotEqual("Started"),
+			wantErr: `"Started" is not an option of property "Status"`,
+		},
+		{
+			name:    "unknown sort property",
+			cond:    Prop("Name").Title().IsNotEmpty(),
+			sorts:   []api.Sort{Desc("Nope")},
+			wantErr: `sorts[0]: unknown property "Nope"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Where(tt.cond).OrderBy(tt.sorts...).Schema(testSchema).Build()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
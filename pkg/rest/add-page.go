@@ -1,76 +1,90 @@
 package rest
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"path"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
 )
 
-// AddPage takes a page object and adds it to the database mentioned in the page object.
+// AddPage takes a page object and adds it to the database mentioned in the page object. With
+// WithPageValidation the properties are checked against the schema of the database first. Title
+// and rich text values longer than Notion's limits are split, see api.SplitProperties.
 func (nc *NotionClient) AddPage(pg api.Page) (*api.Page, error) {
-	client := &http.Client{}
+	return nc.AddPageWithContent(pg, nil)
+}
 
-	u, err := url.Parse(nc.getBaseURL())
-	if err != nil {
-		return nil, fmt.Errorf("parsing the APIURL: %w", err)
+// AddPageWithContent is like AddPage, but also creates the blocks as the content of the page. The
+// first blocks are sent with the page and the rest, e.g. those beyond the first 100, are appended
+// to it, see AppendBlockChildren. If appending fails, the page created is returned with the error.
+// A page created from a template cannot have content.
+func (nc *NotionClient) AddPageWithContent(pg api.Page, children []blocks.Block) (*api.Page, error) {
+	if pg.Template != nil && pg.Template.Type != api.PageTemplateTypeNone && len(children) > 0 {
+		return nil, fmt.Errorf("invalid page: a page created from a template cannot have content")
 	}
 
-	u.Path = path.Join(u.Path, SubPathPages)
-	postURL := u.String()
-
-	b := new(bytes.Buffer)
-	if err := json.NewEncoder(b).Encode(pg); err != nil {
-		return nil, fmt.Errorf("encoding page for request: %w", err)
+	if err := nc.preflight(pg); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", postURL, b)
+	props, err := api.SplitProperties(pg.Properties)
 	if err != nil {
-		return nil, fmt.Errorf("building query: %w", err)
+		return nil, fmt.Errorf("invalid page: %w", err)
 	}
+	pg.Properties = props
 
-	req.Header.Add("Notion-Version", NotionVersion)
-	req.Header.Add("Authorization", "Bearer "+nc.token)
-	req.Header.Add("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("adding a new page: %w", err)
+	if children, err = blocks.SplitBlocks(children); err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
 	}
 
-	defer resp.Body.Close()
+	return nc.createPage(pg, children)
+}
 
-	data, respErr := io.ReadAll(resp.Body)
+// createPageRequest is the body of a page creation.
+type createPageRequest struct {
+	api.Page
+	Children []blocks.Block `json:"children,omitempty"`
+}
 
-	if resp.StatusCode != http.StatusOK {
-		failedResp := api.FailureResponse{}
+// createPage creates the page with the blocks as its content. The blocks that fit in the request
+// within Notion's limits are sent with the page, the others are appended to it afterwards. If
+// appending fails, the page created is returned with the error.
+func (nc *NotionClient) createPage(pg api.Page, children []blocks.Block) (*api.Page, error) {
+	size, err := jsonSize(pg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding page for request: %w", err)
+	}
+	if size+payloadOverhead > MaxPayloadSize {
+		return nil, &LimitError{What: "page", Limit: LimitPayloadSize, Value: size, Max: MaxPayloadSize}
+	}
+
+	batches, err := planAppend(children, size+payloadOverhead)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
 
-		if respErr != nil {
-			log.Debugf("reading the response: %v", respErr)
-		} else {
-			if err := json.Unmarshal(data, &failedResp); err != nil {
-				log.Debugf("unmarshalling failure response: %v", err)
+	// Only blocks sent whole go with the page: Notion does not return the IDs of the blocks
+	// created with it, which are needed to append deferred children.
+	inline := 0
+	if len(batches) > 0 {
+		inline = len(batches[0].blocks)
+		for i := range batches[0].deferred {
+			if i < inline {
+				inline = i
 			}
 		}
-
-		return nil, fmt.Errorf("http request returned non-200 response: %q. Message: %s",
-			resp.Status, failedResp.Message)
 	}
 
-	// Check if there is any error while reading the response Data.
-	if respErr != nil {
-		return nil, fmt.Errorf("reading the response: %w", respErr)
+	page := api.Page{}
+	body := createPageRequest{Page: pg, Children: children[:inline:inline]}
+	if err := nc.doRequest("POST", "adding a new page", nil, body, &page, SubPathPages); err != nil {
+		return nil, err
 	}
 
-	page := api.Page{}
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("could not unmarshal response, %w", err)
+	if inline < len(children) {
+		if _, err := nc.appendChildren(page.ID, children[inline:]); err != nil {
+			return &page, fmt.Errorf("appending the content of page %s: %w", page.ID, err)
+		}
 	}
 
 	return &page, nil
@@ -2,12 +2,17 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
 )
 
 func newTestClient(serverURL string) *NotionClient {
@@ -174,3 +179,150 @@ func searchString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestAddPage_SplitsLongText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pg api.Page
+		if err := json.NewDecoder(r.Body).Decode(&pg); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		title := pg.Properties["Name"].Title
+		if len(title) != 3 || api.PlainText(title) != strings.Repeat("é", 4500) {
+			t.Errorf("expected the title split into 3 runs, got %d", len(title))
+		}
+
+		json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.AddPage(api.Page{
+		Parent: api.Parent{Type: api.ParentTypeDataSource, DataSourceID: "ds-1"},
+		Properties: map[string]api.ValueProperty{"Name": {
+			Type:  api.ValuePropertyTypeTitle,
+			Title: []api.RichText{api.NewText(strings.Repeat("é", 4500))},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreatePage_AppendsContent(t *testing.T) {
+	var requests []string
+	server := blockServer(t, &requests)
+	defer server.Close()
+
+	var children []blocks.Block
+	for i := 0; i < 120; i++ {
+		children = append(children, paragraph("line"))
+	}
+	children[50] = paragraph("deep", paragraph("1", paragraph("2", paragraph("3"))))
+
+	client := newTestClient(server.URL)
+	page, err := client.createPage(api.Page{Parent: api.Parent{Type: api.ParentTypePage, PageID: "parent"}}, children)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.ID != "page-123" {
+		t.Errorf("expected page ID %q, got %q", "page-123", page.ID)
+	}
+
+	want := []string{
+		"POST page 50",
+		"PATCH page-123 70",
+		"GET block-51", "GET block-52", "PATCH block-53 1",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestCreatePage_PageTooLarge(t *testing.T) {
+	client := newTestClient("http://127.0.0.1:0")
+	_, err := client.createPage(api.Page{Properties: map[string]api.ValueProperty{
+		"Name": {Type: api.ValuePropertyTypeTitle, Title: api.NewTexts(strings.Repeat("a", 600*1000))},
+	}}, nil)
+
+	var le *LimitError
+	if !errors.As(err, &le) || le.Limit != LimitPayloadSize || le.What != "page" {
+		t.Errorf("expected a payload size LimitError for the page, got %v", err)
+	}
+}
+
+func TestAddPageWithContent(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Icon     *api.Icon      `json:"icon"`
+			Cover    *api.File      `json:"cover"`
+			Children []blocks.Block `json:"children"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		requests = append(requests, fmt.Sprintf("%s %s %d", r.Method, r.URL.Path, len(body.Children)))
+
+		if r.URL.Path == "/v1/pages" {
+			if body.Icon == nil || body.Icon.Emoji != "📚" || body.Cover == nil || body.Cover.FileUpload.ID != "up-1" {
+				t.Errorf("unexpected icon %+v or cover %+v", body.Icon, body.Cover)
+			}
+			if got := body.Children[0].Paragraph.RichText; len(got) != 2 {
+				t.Errorf("expected the long paragraph split into 2 runs, got %d", len(got))
+			}
+			json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123"}})
+			return
+		}
+		json.NewEncoder(w).Encode(blocks.BlockResponseList{Results: body.Children})
+	}))
+	defer server.Close()
+
+	children := []blocks.Block{paragraph(strings.Repeat("a", 3000))}
+	for i := 0; i < 149; i++ {
+		children = append(children, paragraph("line"))
+	}
+
+	client := newTestClient(server.URL)
+	page, err := client.AddPageWithContent(api.Page{
+		Parent: api.Parent{Type: api.ParentTypePage, PageID: "parent"},
+		Icon:   api.NewEmojiIcon("📚"),
+		Cover:  api.NewFileUploadCover("up-1"),
+	}, children)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.ID != "page-123" {
+		t.Errorf("expected page ID %q, got %q", "page-123", page.ID)
+	}
+
+	want := []string{"POST /v1/pages 100", "PATCH /v1/blocks/page-123/children 50"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestAddPageWithContent_AppendFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/pages" {
+			json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123"}})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "invalid block"})
+	}))
+	defer server.Close()
+
+	var children []blocks.Block
+	for i := 0; i < 101; i++ {
+		children = append(children, paragraph("line"))
+	}
+
+	client := newTestClient(server.URL)
+	page, err := client.AddPageWithContent(api.Page{}, children)
+	if err == nil || !contains(err.Error(), "appending the content of page page-123") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if page == nil || page.ID != "page-123" {
+		t.Errorf("expected the page created to be returned, got %+v", page)
+	}
+}
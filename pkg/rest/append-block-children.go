@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+// maxAppendChildren is the maximum number of blocks Notion accepts in one append request.
+const maxAppendChildren = 100
+
+// AppendBlockChildren takes a block or page id and appends the blocks to its children, and returns
+// the blocks created. Rich text longer than Notion's limits is split first, see blocks.SplitBlocks.
+// The blocks are sent in as many requests as needed to stay within Notion's request limits, and
+// children nested too deep for a request are appended to their parent once it is created. A
+// LimitError is returned for a block that cannot be split that way. On failure the blocks created
+// so far are returned with the error.
+func (nc *NotionClient) AppendBlockChildren(id string, children []blocks.Block) ([]blocks.Block, error) {
+	children, err := blocks.SplitBlocks(children)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
+
+	return nc.appendChildren(id, children)
+}
+
+// appendChildren is AppendBlockChildren without the splitting of rich text.
+func (nc *NotionClient) appendChildren(id string, children []blocks.Block) ([]blocks.Block, error) {
+	batches, err := planAppend(children, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blocks: %w", err)
+	}
+
+	var ret []blocks.Block
+	for _, batch := range batches {
+		body := struct {
+			Children []blocks.Block `json:"children"`
+		}{batch.blocks}
+
+		list := blocks.BlockResponseList{}
+		if err := nc.doRequest("PATCH", "appending block children", nil, body, &list, SubPathBlocks, id, "children"); err != nil {
+			return ret, err
+		}
+		ret = append(ret, list.Results...)
+
+		if err := nc.appendDeferred(list.Results, batch.deferred); err != nil {
+			return ret, err
+		}
+	}
+
+	return ret, nil
+}
+
+// appendDeferred appends the children left out of a request to the blocks created by it. Notion
+// returns the blocks sent in order, but not their children, which are listed to find the block
+// a deferral goes to.
+func (nc *NotionClient) appendDeferred(created []blocks.Block, deferred map[int][]deferral) error {
+	listed := map[string][]blocks.Block{}
+
+	for i := 0; len(deferred) > 0; i++ {
+		defs, ok := deferred[i]
+		if !ok {
+			continue
+		}
+		delete(deferred, i)
+
+		if i >= len(created) {
+			return fmt.Errorf("appending children: block %d of the request was not returned", i)
+		}
+
+		for _, d := range defs {
+			id := created[i].ID
+			for _, j := range d.path {
+				children, ok := listed[id]
+				if !ok {
+					var err error
+					if children, err = nc.ListBlocks(id); err != nil {
+						return fmt.Errorf("listing the children of block %s: %w", id, err)
+					}
+					listed[id] = children
+				}
+				if j >= len(children) {
+					return fmt.Errorf("appending children: block %s has no child %d", id, j)
+				}
+				id = children[j].ID
+			}
+
+			if _, err := nc.appendChildren(id, d.children); err != nil {
+				return fmt.Errorf("appending the children of block %s: %w", id, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+func TestAppendBlockChildren_Batches(t *testing.T) {
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/blocks/page-1/children" {
+			t.Errorf("expected path /v1/blocks/page-1/children, got %s", r.URL.Path)
+		}
+
+		var body struct {
+			Children []blocks.Block `json:"children"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		for _, b := range body.Children {
+			for _, rt := range b.Paragraph.RichText {
+				if len(rt.Text.Content) > api.MaxRichTextLength {
+					t.Errorf("run of %d characters sent", len(rt.Text.Content))
+				}
+			}
+		}
+		sizes = append(sizes, len(body.Children))
+
+		json.NewEncoder(w).Encode(blocks.BlockResponseList{Results: body.Children})
+	}))
+	defer server.Close()
+
+	var children []blocks.Block
+	for i := 0; i < 149; i++ {
+		children = append(children, blocks.Block{Type: &blocks.BTParagraph, Paragraph: &blocks.Property{
+			RichText: []api.RichText{api.NewText("line")},
+		}})
+	}
+	children = append(children, blocks.Block{Type: &blocks.BTParagraph, Paragraph: &blocks.Property{
+		RichText: []api.RichText{api.NewText(strings.Repeat("a", 4500))},
+	}})
+
+	client := newTestClient(server.URL)
+	got, err := client.AppendBlockChildren("page-1", children)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 150 {
+		t.Errorf("expected 150 blocks, got %d", len(got))
+	}
+	if len(sizes) != 2 || sizes[0] != 100 || sizes[1] != 50 {
+		t.Errorf("unexpected batches %v", sizes)
+	}
+}
+
+func TestAppendBlockChildren_InvalidBlock(t *testing.T) {
+	var cells [][]api.RichText
+	var cell []api.RichText
+	for i := 0; i < 101; i++ {
+		cell = append(cell, api.NewEquation("x"))
+	}
+	cells = append(cells, cell)
+
+	client := newTestClient("http://127.0.0.1:0")
+	_, err := client.AppendBlockChildren("page-1", []blocks.Block{{Type: &blocks.BTTableRow, TableRow: &blocks.TableRow{Cells: cells}}})
+	if err == nil || !contains(err.Error(), "block 0: cell 0 has 101 rich text elements") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+// blockServer creates the blocks it is sent, with the IDs block-1, block-2... in depth-first order,
+// and lists the children of the blocks created. It logs the requests by method, block and number of
+// children sent. Pages created get the ID page-123.
+func blockServer(t *testing.T, requests *[]string) *httptest.Server {
+	var mu sync.Mutex
+	created := map[string][]blocks.Block{}
+	next := 0
+
+	var create func(parent string, bs []blocks.Block) []blocks.Block
+	create = func(parent string, bs []blocks.Block) []blocks.Block {
+		ret := make([]blocks.Block, len(bs))
+		for i, b := range bs {
+			next++
+			id := fmt.Sprintf("block-%d", next)
+			children := create(id, b.Children())
+
+			ret[i] = b.WithoutChildren()
+			ret[i].ID = id
+			ret[i].HasChildren = len(children) > 0
+		}
+		created[parent] = append(created[parent], ret...)
+		return ret
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == "GET" {
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+			*requests = append(*requests, "GET "+id)
+			json.NewEncoder(w).Encode(blocks.BlockResponseList{Results: created[id]})
+			return
+		}
+
+		var body struct {
+			Children []blocks.Block `json:"children"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		for _, b := range body.Children {
+			if d := nestingDepth(b); d > MaxNestingDepth {
+				t.Errorf("request with children nested %d levels deep", d)
+			}
+		}
+
+		if r.URL.Path == "/v1/pages" {
+			*requests = append(*requests, fmt.Sprintf("POST page %d", len(body.Children)))
+			create("page-123", body.Children)
+			json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123"}})
+			return
+		}
+
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/blocks/"), "/children")
+		*requests = append(*requests, fmt.Sprintf("PATCH %s %d", id, len(body.Children)))
+		json.NewEncoder(w).Encode(blocks.BlockResponseList{Results: create(id, body.Children)})
+	}))
+}
+
+func TestAppendBlockChildren_DeepNesting(t *testing.T) {
+	var requests []string
+	server := blockServer(t, &requests)
+	defer server.Close()
+
+	toggle := blocks.Block{Type: &blocks.BTToggle, Toggle: &blocks.Property{
+		RichText: api.NewTexts("toggle"),
+		Children: []blocks.Block{paragraph("inside")},
+	}}
+
+	client := newTestClient(server.URL)
+	got, err := client.AppendBlockChildren("page-1", []blocks.Block{
+		paragraph("a"),
+		paragraph("b", paragraph("c", paragraph("d", paragraph("e")))),
+		columnList(column(paragraph("f")), column(toggle)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("expected the 3 top-level blocks, got %d", len(got))
+	}
+
+	want := []string{
+		"PATCH page-1 3",
+		"GET block-2", "GET block-3", "PATCH block-4 1",
+		"GET block-5", "GET block-8", "PATCH block-9 1",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
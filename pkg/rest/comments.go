@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/url"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// ListComments takes a page or block id and returns all the unresolved comments on it, across all
+// of its discussion threads. Group the comments by DiscussionID to get the threads.
+func (nc *NotionClient) ListComments(blockID string) ([]api.Comment, error) {
+	hasMore := true
+	startCursor := ""
+	var ret []api.Comment
+
+	for hasMore {
+		q := url.Values{}
+		q.Add("block_id", blockID)
+		q.Add("page_size", "100")
+		if startCursor != "" {
+			q.Add("start_cursor", startCursor)
+		}
+
+		comments := api.CommentResponseList{}
+		if err := nc.doRequest("GET", "listing comments", q, nil, &comments, SubPathComments); err != nil {
+			return nil, err
+		}
+
+		hasMore = comments.HasMore
+		startCursor = comments.NextCursor
+
+		ret = append(ret, comments.Results...)
+	}
+
+	return ret, nil
+}
+
+// CreateComment starts a new discussion on the given parent, which is either a page
+// (api.ParentTypePage) or a block (api.ParentTypeBlock).
+func (nc *NotionClient) CreateComment(parent api.Parent, richText []api.RichText) (*api.Comment, error) {
+	return nc.createComment(api.CreateCommentRequest{Parent: &parent, RichText: richText})
+}
+
+// ReplyToDiscussion adds a comment to an existing discussion thread.
+func (nc *NotionClient) ReplyToDiscussion(discussionID string, richText []api.RichText) (*api.Comment, error) {
+	return nc.createComment(api.CreateCommentRequest{DiscussionID: discussionID, RichText: richText})
+}
+
+func (nc *NotionClient) createComment(cr api.CreateCommentRequest) (*api.Comment, error) {
+	comment := api.Comment{}
+	if err := nc.doRequest("POST", "creating comment", nil, cr, &comment, SubPathComments); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
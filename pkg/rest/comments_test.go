@@ -0,0 +1,164 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestListComments_Pagination(t *testing.T) {
+	var callCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/comments" {
+			t.Errorf("expected path /v1/comments, got %s", r.URL.Path)
+		}
+		if id := r.URL.Query().Get("block_id"); id != "page-1" {
+			t.Errorf("expected block_id %q, got %q", "page-1", id)
+		}
+
+		if count == 1 {
+			json.NewEncoder(w).Encode(api.CommentResponseList{
+				Response: api.Response{Object: "list", HasMore: true, NextCursor: "cursor-abc"},
+				Results:  []api.Comment{{CommonObject: api.CommonObject{ID: "c-1"}, DiscussionID: "d-1"}},
+			})
+			return
+		}
+
+		if c := r.URL.Query().Get("start_cursor"); c != "cursor-abc" {
+			t.Errorf("expected start_cursor %q, got %q", "cursor-abc", c)
+		}
+		json.NewEncoder(w).Encode(api.CommentResponseList{
+			Response: api.Response{Object: "list"},
+			Results:  []api.Comment{{CommonObject: api.CommonObject{ID: "c-2"}, DiscussionID: "d-1"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	comments, err := client.ListComments("page-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 comments, got %d", len(comments))
+	}
+	if comments[1].ID != "c-2" || comments[1].DiscussionID != "d-1" {
+		t.Errorf("unexpected comment: %+v", comments[1])
+	}
+}
+
+func TestListComments_Non200Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "Insufficient permissions for this endpoint."})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.ListComments("page-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "Insufficient permissions") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestCreateComment_OnPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/comments" {
+			t.Errorf("expected path /v1/comments, got %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var cr api.CreateCommentRequest
+		if err := json.Unmarshal(body, &cr); err != nil {
+			t.Fatalf("unmarshalling request body: %v", err)
+		}
+		if cr.Parent == nil || cr.Parent.PageID != "page-1" {
+			t.Errorf("unexpected parent: %+v", cr.Parent)
+		}
+		if cr.DiscussionID != "" {
+			t.Errorf("expected no discussion_id, got %q", cr.DiscussionID)
+		}
+
+		json.NewEncoder(w).Encode(api.Comment{
+			CommonObject: api.CommonObject{ID: "c-1", Object: "comment"},
+			Parent:       *cr.Parent,
+			DiscussionID: "d-1",
+			RichText:     cr.RichText,
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	comment, err := client.CreateComment(
+		api.Parent{Type: api.ParentTypePage, PageID: "page-1"},
+		[]api.RichText{{Type: "text", Text: &api.Text{Content: "Release notes are ready."}}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.DiscussionID != "d-1" {
+		t.Errorf("expected discussion ID %q, got %q", "d-1", comment.DiscussionID)
+	}
+	if len(comment.RichText) != 1 || comment.RichText[0].Text.Content != "Release notes are ready." {
+		t.Errorf("unexpected rich text: %+v", comment.RichText)
+	}
+}
+
+func TestReplyToDiscussion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var cr api.CreateCommentRequest
+		if err := json.Unmarshal(body, &cr); err != nil {
+			t.Fatalf("unmarshalling request body: %v", err)
+		}
+		if cr.Parent != nil {
+			t.Errorf("expected no parent, got %+v", cr.Parent)
+		}
+		if cr.DiscussionID != "d-1" {
+			t.Errorf("expected discussion ID %q, got %q", "d-1", cr.DiscussionID)
+		}
+
+		json.NewEncoder(w).Encode(api.Comment{CommonObject: api.CommonObject{ID: "c-2"}, DiscussionID: "d-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	comment, err := client.ReplyToDiscussion("d-1", []api.RichText{{Type: "text", Text: &api.Text{Content: "Thanks!"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.ID != "c-2" {
+		t.Errorf("expected ID %q, got %q", "c-2", comment.ID)
+	}
+}
+
+func TestCreateComment_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.CreateComment(api.Parent{Type: api.ParentTypeBlock, BlockID: "block-1"}, nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "creating comment") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
@@ -0,0 +1,318 @@
+package rest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+// expiryMargin is how long before its expiry time a Notion-hosted URL is considered expired, so
+// that a download does not start on a URL that expires midway.
+const expiryMargin = time.Minute
+
+// Downloader downloads the files behind media blocks and files properties. Notion-hosted file URLs
+// are only valid for about an hour, so when a URL has expired the Downloader re-fetches the owning
+// block or page to get a fresh one.
+//
+// Files downloaded into a directory are named after the SHA-256 of their contents, so identical
+// files are only stored once.
+type Downloader struct {
+	nc     *NotionClient
+	client *http.Client
+	now    func() time.Time
+
+	// Paths of the files downloaded, keyed by directory and SHA-256 of their contents.
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// NewDownloader returns a Downloader that uses the Notion client to refresh expired file URLs.
+func (nc *NotionClient) NewDownloader() *Downloader {
+	return &Downloader{
+		nc:     nc,
+		client: &http.Client{},
+		now:    time.Now,
+		hashes: map[string]string{},
+	}
+}
+
+// fileSource is a downloadable file and a way to get a fresh URL for it.
+type fileSource struct {
+	name     string
+	external string
+	hosted   *api.NotionFile
+
+	// refresh re-fetches the owner of the file and returns the up to date Notion-hosted file.
+	refresh func() (*api.NotionFile, error)
+}
+
+// DownloadBlock writes the file of an image, video, audio, file or pdf block to w.
+func (d *Downloader) DownloadBlock(b blocks.Block, w io.Writer) error {
+	src, err := d.blockSource(b)
+	if err != nil {
+		return err
+	}
+
+	return d.download(src, w)
+}
+
+// DownloadBlockToDir downloads the file of an image, video, audio, file or pdf block into dir and
+// returns the path of the file.
+func (d *Downloader) DownloadBlockToDir(b blocks.Block, dir string) (string, error) {
+	src, err := d.blockSource(b)
+	if err != nil {
+		return "", err
+	}
+
+	return d.downloadToDir(src, dir)
+}
+
+// DownloadPropertyFile writes the file at index of the files property of the page to w.
+func (d *Downloader) DownloadPropertyFile(pg api.Page, property string, index int, w io.Writer) error {
+	src, err := d.propertySource(pg, property, index)
+	if err != nil {
+		return err
+	}
+
+	return d.download(src, w)
+}
+
+// DownloadPropertyFiles downloads all the files of the files property of the page into dir and
+// returns their paths, in the order of the property value.
+func (d *Downloader) DownloadPropertyFiles(pg api.Page, property string, dir string) ([]string, error) {
+	var ret []string
+
+	for i := range pg.Properties[property].Files {
+		src, err := d.propertySource(pg, property, i)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := d.downloadToDir(src, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret, nil
+}
+
+func (d *Downloader) blockSource(b blocks.Block) (fileSource, error) {
+	fb := fileBlockOf(b)
+	if fb == nil {
+		return fileSource{}, fmt.Errorf("block %s has no file", b.ID)
+	}
+
+	src := fileSource{name: fb.Name, hosted: fb.File}
+	if fb.External != nil {
+		src.external = fb.External.URL
+	}
+
+	src.refresh = func() (*api.NotionFile, error) {
+		fresh, err := d.nc.GetBlock(b.ID)
+		if err != nil {
+			return nil, fmt.Errorf("refreshing block %s: %w", b.ID, err)
+		}
+
+		fb := fileBlockOf(*fresh)
+		if fb == nil || fb.File == nil {
+			return nil, fmt.Errorf("refreshed block %s has no Notion-hosted file", b.ID)
+		}
+
+		return fb.File, nil
+	}
+
+	return src, nil
+}
+
+func (d *Downloader) propertySource(pg api.Page, property string, index int) (fileSource, error) {
+	files := pg.Properties[property].Files
+	if index < 0 || index >= len(files) {
+		return fileSource{}, fmt.Errorf("page %s has no file %d in property %q", pg.ID, index, property)
+	}
+
+	f := files[index]
+	src := fileSource{name: f.Name, hosted: f.File}
+	if f.External != nil {
+		src.external = f.External.URL
+	}
+
+	src.refresh = func() (*api.NotionFile, error) {
+		fresh, err := d.nc.GetPage(pg.ID)
+		if err != nil {
+			return nil, fmt.Errorf("refreshing page %s: %w", pg.ID, err)
+		}
+
+		files := fresh.Properties[property].Files
+		if index >= len(files) || files[index].File == nil {
+			return nil, fmt.Errorf("refreshed page %s has no Notion-hosted file %d in property %q",
+				pg.ID, index, property)
+		}
+
+		return files[index].File, nil
+	}
+
+	return src, nil
+}
+
+// fileBlockOf returns the file of a media block, or nil if the block is not a media block.
+func fileBlockOf(b blocks.Block) *blocks.FileBlock {
+	for _, fb := range []*blocks.FileBlock{b.Image, b.Video, b.Audio, b.File, b.PDF} {
+		if fb != nil {
+			return fb
+		}
+	}
+
+	return nil
+}
+
+// download writes the file to w. The URL of a Notion-hosted file is refreshed if it has expired,
+// or if the download is refused because it expired in the meantime.
+func (d *Downloader) download(src fileSource, w io.Writer) error {
+	if src.external != "" {
+		_, err := d.get(src.external, w)
+		return err
+	}
+
+	if src.hosted == nil {
+		return fmt.Errorf("file %q has no URL", src.name)
+	}
+
+	refreshed := false
+	if d.expired(src.hosted) {
+		if err := d.refreshSource(&src); err != nil {
+			return err
+		}
+		refreshed = true
+	}
+
+	status, err := d.get(src.hosted.URL, w)
+	if err == nil || refreshed || (status != http.StatusForbidden && status != http.StatusBadRequest) {
+		return err
+	}
+
+	if err := d.refreshSource(&src); err != nil {
+		return err
+	}
+
+	_, err = d.get(src.hosted.URL, w)
+	return err
+}
+
+func (d *Downloader) refreshSource(src *fileSource) error {
+	f, err := src.refresh()
+	if err != nil {
+		return err
+	}
+
+	src.hosted = f
+	return nil
+}
+
+// expired reports whether the URL of the file is expired or about to expire.
+func (d *Downloader) expired(f *api.NotionFile) bool {
+	if f.ExpiryTime == "" {
+		return false
+	}
+
+	expiry, err := f.ExpiresAt()
+	if err != nil {
+		return false
+	}
+
+	return !d.now().Add(expiryMargin).Before(expiry)
+}
+
+// get downloads the URL into w and returns the response status code. Nothing is written to w if
+// the status code is not 200.
+func (d *Downloader) get(u string, w io.Writer) (int, error) {
+	resp, err := d.client.Get(u)
+	if err != nil {
+		return 0, fmt.Errorf("downloading file: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("downloading file returned non-200 response: %q", resp.Status)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return resp.StatusCode, fmt.Errorf("writing file: %w", err)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// downloadToDir downloads the file into dir, naming it after the SHA-256 of its contents followed
+// by the extension of the original file name. If the same contents were downloaded into dir before,
+// the existing file is kept and its path returned.
+func (d *Downloader) downloadToDir(src fileSource, dir string) (string, error) {
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	err = d.download(src, io.MultiWriter(tmp, h))
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("closing temporary file: %w", cerr)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	target := filepath.Join(dir, sum+fileExt(src))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := dir + "\x00" + sum
+	if p, ok := d.hashes[key]; ok {
+		return p, nil
+	}
+
+	if _, err := os.Stat(target); err != nil {
+		if err := os.Rename(tmp.Name(), target); err != nil {
+			return "", fmt.Errorf("moving downloaded file: %w", err)
+		}
+	}
+
+	d.hashes[key] = target
+	return target, nil
+}
+
+// fileExt returns the extension of the file name, or of the URL path if the file has no name.
+func fileExt(src fileSource) string {
+	if ext := filepath.Ext(src.name); ext != "" {
+		return ext
+	}
+
+	raw := src.external
+	if src.hosted != nil {
+		raw = src.hosted.URL
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+
+	return path.Ext(u.Path)
+}
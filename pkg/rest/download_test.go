@@ -0,0 +1,229 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+var downloadNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDownloader(serverURL string) *Downloader {
+	d := newTestClient(serverURL).NewDownloader()
+	d.now = func() time.Time { return downloadNow }
+	return d
+}
+
+func imageBlock(id, url, expiry string) blocks.Block {
+	bt := blocks.BTImage
+	return blocks.Block{
+		CommonObject: api.CommonObject{ID: id},
+		Type:         &bt,
+		Image:        &blocks.FileBlock{Type: "file", File: &blocks.NotionFile{URL: url, ExpiryTime: expiry}},
+	}
+}
+
+func TestDownloadBlock_ValidURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/a.png" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("expected no Authorization header on file download")
+		}
+		w.Write([]byte("image-bytes"))
+	}))
+	defer server.Close()
+
+	d := newTestDownloader(server.URL)
+	var buf bytes.Buffer
+	err := d.DownloadBlock(imageBlock("block-1", server.URL+"/files/a.png", "2025-01-01T13:00:00.000Z"), &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "image-bytes" {
+		t.Errorf("unexpected contents: %q", buf.String())
+	}
+}
+
+func TestDownloadBlock_ExpiredURL(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/blocks/block-1":
+			json.NewEncoder(w).Encode(imageBlock("block-1", server.URL+"/files/fresh.png", "2025-01-01T13:00:00.000Z"))
+		case "/files/fresh.png":
+			w.Write([]byte("fresh"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	d := newTestDownloader(server.URL)
+	var buf bytes.Buffer
+	err := d.DownloadBlock(imageBlock("block-1", server.URL+"/files/stale.png", "2025-01-01T11:00:00.000Z"), &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "fresh" {
+		t.Errorf("unexpected contents: %q", buf.String())
+	}
+}
+
+func TestDownloadBlock_RefusedURLIsRefreshed(t *testing.T) {
+	var refreshes int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/blocks/block-1":
+			atomic.AddInt32(&refreshes, 1)
+			json.NewEncoder(w).Encode(imageBlock("block-1", server.URL+"/files/fresh.png", "2025-01-01T13:00:00.000Z"))
+		case "/files/stale.png":
+			w.WriteHeader(http.StatusForbidden)
+		case "/files/fresh.png":
+			w.Write([]byte("fresh"))
+		}
+	}))
+	defer server.Close()
+
+	d := newTestDownloader(server.URL)
+	var buf bytes.Buffer
+	err := d.DownloadBlock(imageBlock("block-1", server.URL+"/files/stale.png", "2025-01-01T13:00:00.000Z"), &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "fresh" {
+		t.Errorf("unexpected contents: %q", buf.String())
+	}
+	if refreshes != 1 {
+		t.Errorf("expected 1 refresh, got %d", refreshes)
+	}
+}
+
+func TestDownloadBlock_NotAFileBlock(t *testing.T) {
+	d := newTestClient("http://localhost").NewDownloader()
+	err := d.DownloadBlock(blocks.Block{CommonObject: api.CommonObject{ID: "block-1"}}, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "has no file") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestDownloadPropertyFiles_Dedupe(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pages/page-1":
+			json.NewEncoder(w).Encode(api.Page{
+				CommonObject: api.CommonObject{ID: "page-1"},
+				Properties: map[string]api.ValueProperty{
+					"Files": {Type: api.ValuePropertyTypeFiles, Files: []api.File{
+						{Name: "a.pdf", Type: "file", File: &api.NotionFile{URL: server.URL + "/files/a.pdf", ExpiryTime: "2025-01-01T13:00:00.000Z"}},
+					}},
+				},
+			})
+		case "/files/a.pdf", "/files/copy.pdf":
+			w.Write([]byte("same contents"))
+		case "/files/other.txt":
+			w.Write([]byte("other contents"))
+		}
+	}))
+	defer server.Close()
+
+	pg := api.Page{
+		CommonObject: api.CommonObject{ID: "page-1"},
+		Properties: map[string]api.ValueProperty{
+			"Files": {Type: api.ValuePropertyTypeFiles, Files: []api.File{
+				{Name: "a.pdf", Type: "file", File: &api.NotionFile{URL: server.URL + "/files/expired.pdf", ExpiryTime: "2025-01-01T11:00:00.000Z"}},
+				{Name: "copy.pdf", Type: "external", External: &api.External{URL: server.URL + "/files/copy.pdf"}},
+				{Name: "other.txt", Type: "external", External: &api.External{URL: server.URL + "/files/other.txt"}},
+			}},
+		},
+	}
+
+	dir := t.TempDir()
+	d := newTestDownloader(server.URL)
+	paths, err := d.DownloadPropertyFiles(pg, "Files", dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(paths))
+	}
+	if paths[0] != paths[1] {
+		t.Errorf("expected identical files to share a path, got %q and %q", paths[0], paths[1])
+	}
+	if paths[0] == paths[2] {
+		t.Errorf("expected different files to have different paths, got %q", paths[0])
+	}
+	if filepath.Ext(paths[0]) != ".pdf" || filepath.Ext(paths[2]) != ".txt" {
+		t.Errorf("unexpected extensions: %v", paths)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected 2 files in dir, got %d", len(entries))
+	}
+
+	data, err := os.ReadFile(paths[2])
+	if err != nil {
+		t.Fatalf("reading file: %v", err)
+	}
+	if string(data) != "other contents" {
+		t.Errorf("unexpected contents: %q", data)
+	}
+}
+
+func TestDownloadPropertyFiles_TwoDirectories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("same contents"))
+	}))
+	defer server.Close()
+
+	pg := api.Page{Properties: map[string]api.ValueProperty{
+		"Files": {Type: api.ValuePropertyTypeFiles, Files: []api.File{
+			{Name: "a.pdf", Type: "external", External: &api.External{URL: server.URL + "/files/a.pdf"}},
+		}},
+	}}
+
+	d := newTestDownloader(server.URL)
+	for _, dir := range []string{t.TempDir(), t.TempDir()} {
+		paths, err := d.DownloadPropertyFiles(pg, "Files", dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(paths) != 1 || filepath.Dir(paths[0]) != dir {
+			t.Fatalf("expected the file in %s, got %v", dir, paths)
+		}
+		if data, err := os.ReadFile(paths[0]); err != nil || string(data) != "same contents" {
+			t.Errorf("unexpected contents %q, error %v", data, err)
+		}
+	}
+}
+
+func TestDownloadPropertyFile_OutOfRange(t *testing.T) {
+	d := newTestClient("http://localhost").NewDownloader()
+	err := d.DownloadPropertyFile(api.Page{}, "Files", 0, &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "has no file 0") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
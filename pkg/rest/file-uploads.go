@@ -0,0 +1,224 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+var (
+	// maxSinglePartSize is the largest file that is sent in a single part. Larger files are split
+	// into parts of fileUploadPartSize bytes.
+	maxSinglePartSize int64 = 20 << 20
+
+	// fileUploadPartSize is the size of each part of a multi-part upload, except the last one.
+	fileUploadPartSize int64 = 10 << 20
+
+	// fileUploadPollInterval is how often WaitForFileUpload checks the status of an upload.
+	fileUploadPollInterval = time.Second
+)
+
+// CreateFileUpload starts a new file upload. Use SendFileUpload to send the file contents
+// afterwards, or use UploadFile to do the whole flow in one call.
+func (nc *NotionClient) CreateFileUpload(cr api.CreateFileUploadRequest) (*api.FileUpload, error) {
+	fu := api.FileUpload{}
+	if err := nc.doRequest("POST", "creating file upload", nil, cr, &fu, SubPathFileUploads); err != nil {
+		return nil, err
+	}
+
+	return &fu, nil
+}
+
+// GetFileUpload takes a file upload id and returns its current state.
+func (nc *NotionClient) GetFileUpload(id string) (*api.FileUpload, error) {
+	fu := api.FileUpload{}
+	if err := nc.doRequest("GET", "getting file upload", nil, nil, &fu, SubPathFileUploads, id); err != nil {
+		return nil, err
+	}
+
+	return &fu, nil
+}
+
+// SendFileUpload streams the contents read from r to the given file upload. For multi-part uploads
+// partNumber is the 1-based index of the part, for single-part uploads it must be 0.
+func (nc *NotionClient) SendFileUpload(id string, partNumber int, filename, contentType string, r io.Reader) (*api.FileUpload, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeFilePart(mw, partNumber, filename, contentType, r))
+	}()
+
+	fu := api.FileUpload{}
+	err := nc.send("POST", "sending file upload", nil, mw.FormDataContentType(), pr, &fu,
+		SubPathFileUploads, id, "send")
+	// Unblock the writer in case the request failed before the body was read.
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return &fu, nil
+}
+
+// writeFilePart writes the multipart/form-data body of a send request.
+func writeFilePart(mw *multipart.Writer, partNumber int, filename, contentType string, r io.Reader) error {
+	if partNumber > 0 {
+		if err := mw.WriteField("part_number", strconv.Itoa(partNumber)); err != nil {
+			return fmt.Errorf("writing part number: %w", err)
+		}
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(filename)))
+	if contentType != "" {
+		h.Set("Content-Type", contentType)
+	}
+
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return fmt.Errorf("creating file part: %w", err)
+	}
+
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("copying file contents: %w", err)
+	}
+
+	return mw.Close()
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// CompleteFileUpload finishes a multi-part upload once all the parts have been sent.
+func (nc *NotionClient) CompleteFileUpload(id string) (*api.FileUpload, error) {
+	fu := api.FileUpload{}
+	if err := nc.doRequest("POST", "completing file upload", nil, struct{}{}, &fu,
+		SubPathFileUploads, id, "complete"); err != nil {
+		return nil, err
+	}
+
+	return &fu, nil
+}
+
+// UploadFile uploads size bytes read from r to Notion and returns the completed upload. Files
+// larger than 20MB are sent in multiple parts, without holding the whole file in memory. An error
+// is returned if r ends before size bytes, and the part being sent is aborted. Attach the result
+// with api.FileUploadRef{ID: fu.ID}.
+func (nc *NotionClient) UploadFile(filename, contentType string, size int64, r io.Reader) (*api.FileUpload, error) {
+	if size <= maxSinglePartSize {
+		fu, err := nc.CreateFileUpload(api.CreateFileUploadRequest{
+			Mode:        api.FileUploadModeSinglePart,
+			Filename:    filename,
+			ContentType: contentType,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nc.SendFileUpload(fu.ID, 0, filename, contentType, &partReader{r: r, n: size})
+	}
+
+	parts := int((size + fileUploadPartSize - 1) / fileUploadPartSize)
+
+	fu, err := nc.CreateFileUpload(api.CreateFileUploadRequest{
+		Mode:          api.FileUploadModeMultiPart,
+		Filename:      filename,
+		ContentType:   contentType,
+		NumberOfParts: parts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for part := 1; part <= parts; part++ {
+		n := fileUploadPartSize
+		if left := size - int64(part-1)*fileUploadPartSize; left < n {
+			n = left
+		}
+
+		if _, err := nc.SendFileUpload(fu.ID, part, filename, contentType, &partReader{r: r, n: n}); err != nil {
+			return nil, fmt.Errorf("sending part %d of %d: %w", part, parts, err)
+		}
+	}
+
+	return nc.CompleteFileUpload(fu.ID)
+}
+
+// partReader reads the n bytes of a part from r. It fails if r ends before, so that the request
+// sending the part is aborted rather than sending a truncated part.
+type partReader struct {
+	r io.Reader
+	n int64
+}
+
+func (p *partReader) Read(b []byte) (int, error) {
+	if p.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(b)) > p.n {
+		b = b[:p.n]
+	}
+
+	n, err := p.r.Read(b)
+	p.n -= int64(n)
+	if err == io.EOF && p.n > 0 {
+		err = fmt.Errorf("file ended %d bytes before the end of the part: %w", p.n, io.ErrUnexpectedEOF)
+	}
+
+	return n, err
+}
+
+// ImportFileFromURL asks Notion to import the file at the given public URL and waits up to timeout
+// for the import to finish.
+func (nc *NotionClient) ImportFileFromURL(filename, externalURL string, timeout time.Duration) (*api.FileUpload, error) {
+	fu, err := nc.CreateFileUpload(api.CreateFileUploadRequest{
+		Mode:        api.FileUploadModeExternalURL,
+		Filename:    filename,
+		ExternalURL: externalURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nc.WaitForFileUpload(fu.ID, timeout)
+}
+
+// WaitForFileUpload polls the given file upload until it is no longer pending, or until timeout
+// has passed. It returns an error if the upload failed or expired.
+func (nc *NotionClient) WaitForFileUpload(id string, timeout time.Duration) (*api.FileUpload, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		fu, err := nc.GetFileUpload(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch fu.Status {
+		case api.FileUploadStatusUploaded:
+			return fu, nil
+		case api.FileUploadStatusFailed, api.FileUploadStatusExpired:
+			msg := ""
+			if fu.FileImportResult != nil && fu.FileImportResult.Error != nil {
+				msg = fu.FileImportResult.Error.Message
+			}
+			return fu, fmt.Errorf("file upload %s is %s: %s", id, fu.Status, msg)
+		}
+
+		if time.Now().After(deadline) {
+			return fu, fmt.Errorf("timed out waiting for file upload %s, status: %s", id, fu.Status)
+		}
+
+		time.Sleep(fileUploadPollInterval)
+	}
+}
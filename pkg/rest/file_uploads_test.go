@@ -0,0 +1,282 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestUploadFile_SinglePart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/file_uploads":
+			var cr api.CreateFileUploadRequest
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &cr); err != nil {
+				t.Fatalf("unmarshalling request body: %v", err)
+			}
+			if cr.Mode != api.FileUploadModeSinglePart {
+				t.Errorf("expected mode %q, got %q", api.FileUploadModeSinglePart, cr.Mode)
+			}
+			if cr.Filename != "report.pdf" {
+				t.Errorf("expected filename %q, got %q", "report.pdf", cr.Filename)
+			}
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-1"}, Status: api.FileUploadStatusPending})
+		case "/v1/file_uploads/fu-1/send":
+			if r.Header.Get("Notion-Version") != NotionVersion {
+				t.Errorf("expected Notion-Version %s, got %s", NotionVersion, r.Header.Get("Notion-Version"))
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parsing multipart form: %v", err)
+			}
+			if pn := r.FormValue("part_number"); pn != "" {
+				t.Errorf("expected no part_number, got %q", pn)
+			}
+			f, fh, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("reading file part: %v", err)
+			}
+			data, _ := io.ReadAll(f)
+			if string(data) != "%PDF-1.7" {
+				t.Errorf("unexpected file contents: %q", data)
+			}
+			if fh.Filename != "report.pdf" {
+				t.Errorf("expected filename %q, got %q", "report.pdf", fh.Filename)
+			}
+			if ct := fh.Header.Get("Content-Type"); ct != "application/pdf" {
+				t.Errorf("expected content type %q, got %q", "application/pdf", ct)
+			}
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-1"}, Status: api.FileUploadStatusUploaded})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	content := "%PDF-1.7"
+	fu, err := client.UploadFile("report.pdf", "application/pdf", int64(len(content)), strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fu.Status != api.FileUploadStatusUploaded {
+		t.Errorf("expected status %q, got %q", api.FileUploadStatusUploaded, fu.Status)
+	}
+}
+
+func TestUploadFile_MultiPart(t *testing.T) {
+	defer func(single, part int64) { maxSinglePartSize, fileUploadPartSize = single, part }(maxSinglePartSize, fileUploadPartSize)
+	maxSinglePartSize, fileUploadPartSize = 8, 4
+
+	var mu sync.Mutex
+	parts := map[string]string{}
+	completed := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/file_uploads":
+			var cr api.CreateFileUploadRequest
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &cr)
+			if cr.Mode != api.FileUploadModeMultiPart {
+				t.Errorf("expected mode %q, got %q", api.FileUploadModeMultiPart, cr.Mode)
+			}
+			if cr.NumberOfParts != 3 {
+				t.Errorf("expected 3 parts, got %d", cr.NumberOfParts)
+			}
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-2"}})
+		case "/v1/file_uploads/fu-2/send":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parsing multipart form: %v", err)
+			}
+			f, _, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("reading file part: %v", err)
+			}
+			data, _ := io.ReadAll(f)
+			parts[r.FormValue("part_number")] = string(data)
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-2"}})
+		case "/v1/file_uploads/fu-2/complete":
+			completed = true
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-2"}, Status: api.FileUploadStatusUploaded})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	content := "aaaabbbbcc"
+	fu, err := client.UploadFile("big.bin", "application/octet-stream", int64(len(content)), strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fu.Status != api.FileUploadStatusUploaded {
+		t.Errorf("expected status %q, got %q", api.FileUploadStatusUploaded, fu.Status)
+	}
+	if !completed {
+		t.Error("expected upload to be completed")
+	}
+	want := map[string]string{"1": "aaaa", "2": "bbbb", "3": "cc"}
+	for k, v := range want {
+		if parts[k] != v {
+			t.Errorf("expected part %s to be %q, got %q", k, v, parts[k])
+		}
+	}
+}
+
+func TestUploadFile_SendError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/file_uploads" {
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-3"}})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "file too large"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.UploadFile("a.txt", "text/plain", 3, strings.NewReader("abc"))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "file too large") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestUploadFile_ShortReader(t *testing.T) {
+	defer func(single, part int64) { maxSinglePartSize, fileUploadPartSize = single, part }(maxSinglePartSize, fileUploadPartSize)
+	maxSinglePartSize, fileUploadPartSize = 8, 4
+
+	var mu sync.Mutex
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/file_uploads":
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-4"}})
+		case "/v1/file_uploads/fu-4/send":
+			// The body of an aborted part cannot be parsed.
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			sent = append(sent, r.FormValue("part_number"))
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-4"}})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.UploadFile("big.bin", "application/octet-stream", 10, strings.NewReader("aaaabb"))
+	if err == nil || !contains(err.Error(), "sending part 2 of 3") || !contains(err.Error(), "file ended 2 bytes before the end of the part") {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sent) != 1 || sent[0] != "1" {
+		t.Errorf("expected only the first part to be sent, got %v", sent)
+	}
+}
+
+func TestImportFileFromURL_Polling(t *testing.T) {
+	defer func(d time.Duration) { fileUploadPollInterval = d }(fileUploadPollInterval)
+	fileUploadPollInterval = time.Millisecond
+
+	var mu sync.Mutex
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/file_uploads":
+			var cr api.CreateFileUploadRequest
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &cr)
+			if cr.Mode != api.FileUploadModeExternalURL || cr.ExternalURL != "https://example.com/a.png" {
+				t.Errorf("unexpected request: %+v", cr)
+			}
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-4"}, Status: api.FileUploadStatusPending})
+		case r.Method == "GET" && r.URL.Path == "/v1/file_uploads/fu-4":
+			polls++
+			status := api.FileUploadStatusPending
+			if polls == 3 {
+				status = api.FileUploadStatusUploaded
+			}
+			json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-4"}, Status: status})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	fu, err := client.ImportFileFromURL("a.png", "https://example.com/a.png", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fu.Status != api.FileUploadStatusUploaded {
+		t.Errorf("expected status %q, got %q", api.FileUploadStatusUploaded, fu.Status)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+}
+
+func TestWaitForFileUpload_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(api.FileUpload{
+			CommonObject: api.CommonObject{ID: "fu-5"},
+			Status:       api.FileUploadStatusFailed,
+			FileImportResult: &api.FileImportResult{
+				Type:  "error",
+				Error: &api.FileImportError{Code: "download_failed", Message: "404 Not Found"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.WaitForFileUpload("fu-5", time.Minute)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "failed") || !contains(got, "404 Not Found") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestWaitForFileUpload_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(api.FileUpload{CommonObject: api.CommonObject{ID: "fu-6"}, Status: api.FileUploadStatusPending})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.WaitForFileUpload("fu-6", 0)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "timed out") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
@@ -80,11 +80,7 @@ func (nc *NotionClient) FindDatabase(name string) (*api.Database, error) {
 		startCursor = databases.NextCursor
 
 		for _, db := range databases.Results {
-			if len(db.Title) == 0 {
-				continue
-			}
-
-			foundName := db.Title[0].Text.Content
+			foundName := api.PlainText(db.Title)
 			if foundName == "" {
 				continue
 			}
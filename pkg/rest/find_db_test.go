@@ -13,8 +13,8 @@ import (
 func TestFindDatabase_Success_FirstPage(t *testing.T) {
 	expectedDB := api.Database{
 		CommonObject: api.CommonObject{ID: "db-123", Object: "database"},
-		Title: []api.Title{
-			{Text: api.Text{Content: "My Database"}, PlainText: "My Database"},
+		Title: []api.RichText{
+			{Text: &api.Text{Content: "My Database"}, PlainText: "My Database"},
 		},
 	}
 
@@ -62,7 +62,7 @@ func TestFindDatabase_Success_WithPagination(t *testing.T) {
 				Results: []api.Database{
 					{
 						CommonObject: api.CommonObject{ID: "db-other"},
-						Title:        []api.Title{{Text: api.Text{Content: "Other DB"}}},
+						Title:        []api.RichText{{Text: &api.Text{Content: "Other DB"}}},
 					},
 				},
 			})
@@ -76,7 +76,7 @@ func TestFindDatabase_Success_WithPagination(t *testing.T) {
 				Results: []api.Database{
 					{
 						CommonObject: api.CommonObject{ID: "db-target"},
-						Title:        []api.Title{{Text: api.Text{Content: "Target DB"}}},
+						Title:        []api.RichText{{Text: &api.Text{Content: "Target DB"}}},
 					},
 				},
 			})
@@ -105,7 +105,7 @@ func TestFindDatabase_NotFound(t *testing.T) {
 			Results: []api.Database{
 				{
 					CommonObject: api.CommonObject{ID: "db-other"},
-					Title:        []api.Title{{Text: api.Text{Content: "Other DB"}}},
+					Title:        []api.RichText{{Text: &api.Text{Content: "Other DB"}}},
 				},
 			},
 		})
@@ -135,12 +135,12 @@ func TestFindDatabase_SkipsEmptyTitle(t *testing.T) {
 				// Database with empty Content
 				{
 					CommonObject: api.CommonObject{ID: "db-empty-content"},
-					Title:        []api.Title{{Text: api.Text{Content: ""}}},
+					Title:        []api.RichText{{Text: &api.Text{Content: ""}}},
 				},
 				// Target database
 				{
 					CommonObject: api.CommonObject{ID: "db-target"},
-					Title:        []api.Title{{Text: api.Text{Content: "Target DB"}}},
+					Title:        []api.RichText{{Text: &api.Text{Content: "Target DB"}}},
 				},
 			},
 		})
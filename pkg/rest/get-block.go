@@ -0,0 +1,16 @@
+package rest
+
+import (
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+// GetBlock takes a block id and returns the block object. The children of the block are not
+// included, use ListBlocks to get them.
+func (nc *NotionClient) GetBlock(id string) (*blocks.Block, error) {
+	block := blocks.Block{}
+	if err := nc.doRequest("GET", "getting block", nil, nil, &block, SubPathBlocks, id); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
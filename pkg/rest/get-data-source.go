@@ -0,0 +1,15 @@
+package rest
+
+import (
+	"github.com/surajssd/libnotion/api"
+)
+
+// GetDataSource takes a data source id and returns the data source object with its property schema.
+func (nc *NotionClient) GetDataSource(id string) (*api.DataSource, error) {
+	ds := api.DataSource{}
+	if err := nc.doRequest("GET", "getting data source", nil, nil, &ds, SubPathDataSources, id); err != nil {
+		return nil, err
+	}
+
+	return &ds, nil
+}
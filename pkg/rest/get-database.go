@@ -0,0 +1,16 @@
+package rest
+
+import (
+	"github.com/surajssd/libnotion/api"
+)
+
+// GetDatabase takes a database id and returns the database object with the list of its data
+// sources.
+func (nc *NotionClient) GetDatabase(id string) (*api.Database, error) {
+	db := api.Database{}
+	if err := nc.doRequest("GET", "getting database", nil, nil, &db, SubPathDatabases, id); err != nil {
+		return nil, err
+	}
+
+	return &db, nil
+}
@@ -0,0 +1,15 @@
+package rest
+
+import (
+	"github.com/surajssd/libnotion/api"
+)
+
+// GetPage takes a page id and returns the page object with its property values.
+func (nc *NotionClient) GetPage(id string) (*api.Page, error) {
+	page := api.Page{}
+	if err := nc.doRequest("GET", "getting page", nil, nil, &page, SubPathPages, id); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
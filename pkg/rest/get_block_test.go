@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+func TestGetBlock_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/blocks/block-1" {
+			t.Errorf("expected path /v1/blocks/block-1, got %s", r.URL.Path)
+		}
+		bt := blocks.BTImage
+		json.NewEncoder(w).Encode(blocks.Block{
+			CommonObject: api.CommonObject{ID: "block-1"},
+			Type:         &bt,
+			Image:        &blocks.FileBlock{Type: "file", File: &blocks.NotionFile{URL: "https://s3/a.png"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	block, err := client.GetBlock("block-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if block.Image == nil || block.Image.File == nil || block.Image.File.URL != "https://s3/a.png" {
+		t.Errorf("unexpected block: %+v", block)
+	}
+}
+
+func TestGetBlock_Non200Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "Could not find block"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.GetBlock("block-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "Could not find block") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestGetDataSource_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/data_sources/ds-1" {
+			t.Errorf("expected path /v1/data_sources/ds-1, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(api.DataSource{
+			CommonObject: api.CommonObject{ID: "ds-1", Object: "data_source"},
+			Title:        []api.RichText{api.NewText("Books")},
+			Properties: map[string]api.Property{
+				"Name": {ID: "title", Name: "Name", Type: "title", Title: &api.EmptyConfig{}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	ds, err := client.GetDataSource("ds-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if api.PlainText(ds.Title) != "Books" || ds.Properties["Name"].Type != "title" {
+		t.Errorf("unexpected data source: %+v", ds)
+	}
+}
+
+func TestGetDataSource_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.GetDataSource("ds-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "getting data source") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestGetDatabase_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/databases/db-1" {
+			t.Errorf("expected path /v1/databases/db-1, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"object":"database","id":"db-1","data_sources":[{"id":"ds-1","name":"Books"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	db, err := client.GetDatabase("db-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []api.DataSourceReference{{ID: "ds-1", Name: "Books"}}
+	if len(db.DataSources) != 1 || db.DataSources[0] != want[0] {
+		t.Errorf("expected data sources %v, got %v", want, db.DataSources)
+	}
+}
+
+func TestGetDatabase_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "not found"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if _, err := client.GetDatabase("db-1"); err == nil || !contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
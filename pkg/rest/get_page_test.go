@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestGetPage_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/pages/page-1" {
+			t.Errorf("expected path /v1/pages/page-1, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(api.Page{
+			CommonObject: api.CommonObject{ID: "page-1"},
+			Properties: map[string]api.ValueProperty{
+				"Done": {Type: api.ValuePropertyTypeCheckbox, Checkbox: true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	page, err := client.GetPage("page-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !page.Properties["Done"].Checkbox {
+		t.Errorf("unexpected properties: %+v", page.Properties)
+	}
+}
+
+func TestGetPage_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.GetPage("page-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "getting page") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
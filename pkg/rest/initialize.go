@@ -1,9 +1,27 @@
 package rest
 
+import (
+	"sync"
+
+	"github.com/surajssd/libnotion/api"
+)
+
 // NotionClient is used to interact with Notion.
 type NotionClient struct {
 	token   string
 	baseURL string
+
+	// Set by WithPageValidation and WithAutoCreateOptions.
+	validatePages bool
+	createOptions bool
+
+	// Data sources by the id of the data source or database, see schemaFor.
+	schemaMu sync.Mutex
+	schemas  map[string]*api.DataSource
+
+	// Keys being upserted, see lockKeys.
+	upsertMu   sync.Mutex
+	upsertKeys map[string]*keyLock
 }
 
 // NewNotionClient is used to initialize the Notion client.
@@ -39,6 +57,25 @@ func WithBaseURL(baseURL string) notionClientConfigOpt {
 	}
 }
 
+// WithPageValidation makes AddPage check the properties of the page against the schema of its
+// data source before sending it, see api.ValidatePage. The schema is fetched once per data source
+// and cached, ClearSchemaCache drops it after the schema was changed in Notion.
+func WithPageValidation() notionClientConfigOpt {
+	return func(nc *NotionClient) {
+		nc.validatePages = true
+	}
+}
+
+// WithAutoCreateOptions is like WithPageValidation, but select and multi select options that do not
+// exist yet are added to the data source instead of being reported. This needs an integration
+// that can update the data source.
+func WithAutoCreateOptions() notionClientConfigOpt {
+	return func(nc *NotionClient) {
+		nc.validatePages = true
+		nc.createOptions = true
+	}
+}
+
 // getBaseURL returns the base URL for the Notion API. If a custom base URL is set, it returns that;
 // otherwise, it returns the default APIURL.
 func (nc *NotionClient) getBaseURL() string {
@@ -0,0 +1,213 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+const (
+	// MaxPayloadSize is the maximum size of a request body accepted by Notion, in bytes.
+	MaxPayloadSize = 500 * 1000
+
+	// MaxBlockElements is the maximum number of blocks, nested ones included, in a request.
+	MaxBlockElements = 1000
+
+	// MaxNestingDepth is how deep blocks can be nested in a request: the blocks appended or the
+	// content of a page created can have children, which can have children of their own.
+	MaxNestingDepth = 2
+
+	// payloadOverhead is room left in a request for the JSON around the blocks.
+	payloadOverhead = 64
+)
+
+// Limit is one of Notion's request limits.
+type Limit string
+
+const (
+	LimitPayloadSize   = Limit("payload size")
+	LimitBlockElements = Limit("block elements")
+	LimitNestingDepth  = Limit("nesting depth")
+)
+
+// LimitError is returned when a page or a block does not fit in a request, even on its own.
+type LimitError struct {
+	// What does not fit, e.g. "page" or "block 3".
+	What string
+
+	Limit Limit
+
+	// Value is the size in bytes, the number of blocks or the depth of What, and Max the limit.
+	Value, Max int
+}
+
+func (e *LimitError) Error() string {
+	switch e.Limit {
+	case LimitPayloadSize:
+		return fmt.Sprintf("%s: %d bytes, over the %s limit of %d bytes per request", e.What, e.Value, e.Limit, e.Max)
+	case LimitNestingDepth:
+		return fmt.Sprintf("%s: children nested %d levels deep, over the %s limit of %d per request", e.What, e.Value, e.Limit, e.Max)
+	}
+
+	return fmt.Sprintf("%s: %d %s, over the limit of %d per request", e.What, e.Value, e.Limit, e.Max)
+}
+
+// appendBatch is the blocks of one request.
+type appendBatch struct {
+	blocks []blocks.Block
+
+	// Children left out of blocks[i], or out of its descendants, to keep the request within the
+	// limits. They are appended once the blocks are created.
+	deferred map[int][]deferral
+}
+
+// deferral is children left out of a request, to be appended to a block created by it.
+type deferral struct {
+	// Position of the block among the children of each level, from the block sent in the
+	// request, e.g. [1 0] for the first child of its second child. Empty for the block itself.
+	path []int
+
+	children []blocks.Block
+}
+
+// planAppend groups the blocks into requests that stay within Notion's limits, keeping their
+// order. A block too large or too deeply nested for a request is sent with the children that fit,
+// and the others are appended afterwards. The first request already holds reserved bytes, e.g.
+// the properties of the page created with the blocks; it may be left empty.
+func planAppend(bs []blocks.Block, reserved int) ([]appendBatch, error) {
+	var ret []appendBatch
+
+	cur := appendBatch{}
+	used, count := reserved, 0
+	for i, b := range bs {
+		what := fmt.Sprintf("block %d", i)
+
+		var deferred []deferral
+		if depth := nestingDepth(b); depth > MaxNestingDepth {
+			trimmed, defs, ok := trimDepth(b, MaxNestingDepth)
+			if !ok {
+				return nil, &LimitError{What: what, Limit: LimitNestingDepth, Value: depth, Max: MaxNestingDepth}
+			}
+			b, deferred = trimmed, defs
+		}
+
+		size, err := jsonSize(b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		n := countBlocks(b)
+
+		if size > MaxPayloadSize-payloadOverhead || n > MaxBlockElements {
+			if b.RequiresChildren() {
+				if n > MaxBlockElements {
+					return nil, &LimitError{What: what, Limit: LimitBlockElements, Value: n, Max: MaxBlockElements}
+				}
+				return nil, &LimitError{What: what, Limit: LimitPayloadSize, Value: size, Max: MaxPayloadSize}
+			}
+
+			deferred = []deferral{{children: bs[i].Children()}}
+			b = b.WithoutChildren()
+			if size, err = jsonSize(b); err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+			if size > MaxPayloadSize-payloadOverhead {
+				return nil, &LimitError{What: what, Limit: LimitPayloadSize, Value: size, Max: MaxPayloadSize}
+			}
+			n = 1
+		}
+
+		if len(cur.blocks) == maxAppendChildren || count+n > MaxBlockElements ||
+			used+size+payloadOverhead > MaxPayloadSize {
+			ret = append(ret, cur)
+			cur = appendBatch{}
+			used, count = 0, 0
+		}
+
+		if deferred != nil {
+			if cur.deferred == nil {
+				cur.deferred = map[int][]deferral{}
+			}
+			cur.deferred[len(cur.blocks)] = deferred
+		}
+		cur.blocks = append(cur.blocks, b)
+		used += size + 1
+		count += n
+	}
+
+	if len(cur.blocks) > 0 {
+		ret = append(ret, cur)
+	}
+
+	return ret, nil
+}
+
+// trimDepth returns a copy of b whose children are nested at most depth levels deep, and the
+// children left out. A block keeps the children before the first one that does not fit, the rest
+// is deferred. It returns false if a block that requires children would be left without any.
+func trimDepth(b blocks.Block, depth int) (blocks.Block, []deferral, bool) {
+	children := b.Children()
+	if len(children) == 0 {
+		return b, nil, true
+	}
+	if depth == 0 {
+		if b.RequiresChildren() {
+			return b, nil, false
+		}
+		return b.WithoutChildren(), []deferral{{children: children}}, true
+	}
+
+	var kept []blocks.Block
+	var deferred []deferral
+	for j, c := range children {
+		trimmed, defs, ok := trimDepth(c, depth-1)
+		if !ok {
+			break
+		}
+
+		kept = append(kept, trimmed)
+		for _, d := range defs {
+			deferred = append(deferred, deferral{path: append([]int{j}, d.path...), children: d.children})
+		}
+	}
+
+	if len(kept) < len(children) {
+		if len(kept) == 0 && b.RequiresChildren() {
+			return b, nil, false
+		}
+		deferred = append(deferred, deferral{children: children[len(kept):]})
+	}
+
+	return b.WithChildren(kept), deferred, true
+}
+
+func jsonSize(v interface{}) (int, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf("encoding: %w", err)
+	}
+
+	return len(data), nil
+}
+
+// countBlocks returns the number of blocks in b, its nested children included.
+func countBlocks(b blocks.Block) int {
+	n := 1
+	for _, c := range b.Children() {
+		n += countBlocks(c)
+	}
+
+	return n
+}
+
+// nestingDepth returns how many levels of children b has.
+func nestingDepth(b blocks.Block) int {
+	depth := 0
+	for _, c := range b.Children() {
+		if d := nestingDepth(c) + 1; d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+}
@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+func paragraph(text string, children ...blocks.Block) blocks.Block {
+	return blocks.Block{Type: &blocks.BTParagraph, Paragraph: &blocks.Property{
+		RichText: api.NewTexts(text),
+		Children: children,
+	}}
+}
+
+func TestPlanAppend_Batches(t *testing.T) {
+	var bs []blocks.Block
+	for i := 0; i < 250; i++ {
+		bs = append(bs, paragraph("line"))
+	}
+
+	batches, err := planAppend(bs, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 3 || len(batches[0].blocks) != 100 || len(batches[2].blocks) != 50 {
+		t.Errorf("expected batches of 100, 100 and 50 blocks, got %d", len(batches))
+	}
+}
+
+func TestPlanAppend_BlockElements(t *testing.T) {
+	var children []blocks.Block
+	for i := 0; i < 399; i++ {
+		children = append(children, paragraph("child"))
+	}
+	bs := []blocks.Block{paragraph("a", children...), paragraph("b", children...), paragraph("c", children...)}
+
+	batches, err := planAppend(bs, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0].blocks) != 2 || len(batches[1].blocks) != 1 {
+		t.Errorf("expected the third block in a second request, got %d requests", len(batches))
+	}
+}
+
+func TestPlanAppend_PayloadSize(t *testing.T) {
+	big := paragraph(strings.Repeat("a", 150*1000))
+	bs := []blocks.Block{big, big, big, big}
+
+	batches, err := planAppend(bs, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0].blocks) != 3 {
+		t.Errorf("expected 3 blocks in the first request, got %d requests", len(batches))
+	}
+
+	batches, err = planAppend(bs, 400*1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 3 || len(batches[0].blocks) != 0 {
+		t.Errorf("expected the first request to be left empty, got %d requests", len(batches))
+	}
+}
+
+func TestPlanAppend_DefersChildren(t *testing.T) {
+	deep := paragraph("1", paragraph("2", paragraph("3", paragraph("4"))))
+
+	batches, err := planAppend([]blocks.Block{paragraph("0"), deep}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].blocks) != 2 {
+		t.Fatalf("expected a single request, got %d", len(batches))
+	}
+	if got := nestingDepth(batches[0].blocks[1]); got != MaxNestingDepth {
+		t.Errorf("expected the deep block to be sent with 2 levels of children, got %d", got)
+	}
+	got := batches[0].deferred[1]
+	if len(got) != 1 || !reflect.DeepEqual(got[0].path, []int{0, 0}) || api.PlainText(got[0].children[0].Paragraph.RichText) != "4" {
+		t.Errorf("expected the 4th level to be deferred, got %+v", got)
+	}
+	if got := deep.Children(); len(got) != 1 {
+		t.Error("expected the blocks not to be modified")
+	}
+}
+
+func column(children ...blocks.Block) blocks.Block {
+	return blocks.Block{Type: &blocks.BTColumn, Column: &blocks.Column{Children: children}}
+}
+
+func columnList(columns ...blocks.Block) blocks.Block {
+	return blocks.Block{Type: &blocks.BTColumnList, ColumnList: &blocks.ColumnList{Children: columns}}
+}
+
+func TestPlanAppend_DefersInsideRequiredChildren(t *testing.T) {
+	toggle := blocks.Block{Type: &blocks.BTToggle, Toggle: &blocks.Property{
+		RichText: api.NewTexts("toggle"),
+		Children: []blocks.Block{paragraph("inside")},
+	}}
+	columns := columnList(column(paragraph("a")), column(paragraph("b"), toggle, paragraph("c")))
+
+	batches, err := planAppend([]blocks.Block{columns}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].blocks) != 1 {
+		t.Fatalf("expected a single request, got %d", len(batches))
+	}
+
+	sent := batches[0].blocks[0]
+	if got := nestingDepth(sent); got != MaxNestingDepth {
+		t.Errorf("expected the column list to be sent with 2 levels of children, got %d", got)
+	}
+	if got := sent.Children()[1].Children(); len(got) != 3 || got[1].Children() != nil {
+		t.Errorf("expected the second column with its 3 blocks and a childless toggle, got %+v", got)
+	}
+
+	got := batches[0].deferred[0]
+	if len(got) != 1 || !reflect.DeepEqual(got[0].path, []int{1, 1}) || len(got[0].children) != 1 {
+		t.Errorf("expected the child of the toggle to be deferred, got %+v", got)
+	}
+}
+
+func TestPlanAppend_Errors(t *testing.T) {
+	tableOf := func(rows ...blocks.Block) blocks.Block {
+		return blocks.Block{Type: &blocks.BTTable, Table: &blocks.TableBlock{TableWidth: 1, Children: rows}}
+	}
+	row := blocks.Block{Type: &blocks.BTTableRow, TableRow: &blocks.TableRow{}}
+	columns := columnList(column(tableOf(row)))
+
+	var rows []blocks.Block
+	for i := 0; i < 1000; i++ {
+		rows = append(rows, blocks.Block{Type: &blocks.BTTableRow, TableRow: &blocks.TableRow{}})
+	}
+	table := tableOf(rows...)
+
+	tests := []struct {
+		name  string
+		block blocks.Block
+		limit Limit
+		msg   string
+	}{
+		{
+			name:  "nesting depth",
+			block: columns,
+			limit: LimitNestingDepth,
+			msg:   "block 1: children nested 3 levels deep, over the nesting depth limit of 2 per request",
+		},
+		{
+			name:  "block elements",
+			block: table,
+			limit: LimitBlockElements,
+			msg:   "block 1: 1001 block elements, over the limit of 1000 per request",
+		},
+		{
+			name:  "payload size",
+			block: blocks.Block{Type: &blocks.BTEquation, Equation: &blocks.EquationBlock{Expression: strings.Repeat("x", 500*1000)}},
+			limit: LimitPayloadSize,
+			msg:   "block 1: 500048 bytes, over the payload size limit of 500000 bytes per request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planAppend([]blocks.Block{paragraph("first"), tt.block}, 0)
+
+			var le *LimitError
+			if !errors.As(err, &le) || le.Limit != tt.limit {
+				t.Fatalf("expected a %s LimitError, got %v", tt.limit, err)
+			}
+			if err.Error() != tt.msg {
+				t.Errorf("unexpected error message %q", err.Error())
+			}
+		})
+	}
+}
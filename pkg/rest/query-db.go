@@ -15,8 +15,15 @@ import (
 )
 
 // QueryDatabase takes database id and a query object and returns list of pages based on the query.
-// Set appropriate parameters in the query object to get the relevant results.
+// Set appropriate parameters in the query object to get the relevant results. The filter of the
+// query is validated before any request is made.
 func (nc *NotionClient) QueryDatabase(id string, query *api.QueryDB) ([]api.Page, error) {
+	if query != nil && query.Filter != nil {
+		if err := query.Filter.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+	}
+
 	hasMore := true
 	startCursor := ""
 	client := &http.Client{}
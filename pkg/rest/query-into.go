@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// RowError is a page of a query that could not be fully decoded by QueryInto.
+type RowError struct {
+	// Index of the row in the results.
+	Index int
+
+	// ID of the page.
+	PageID string
+
+	// Err is usually an api.PropertyErrors listing the properties that failed to convert.
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d (page %s): %v", e.Index, e.PageID, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// QueryInto queries the data source like QueryDatabase and decodes every page into a T with
+// api.UnmarshalPage, so T is a struct with `notion` tags:
+//
+//	books, rowErrs, err := rest.QueryInto[Book](nc, id, query)
+//
+// T is checked before the query is sent, see api.CheckUnmarshalTarget. A page that fails to decode
+// does not abort the query: the row is still returned with the properties that did convert, and
+// its error is reported in the returned RowErrors. The error is only set when T is not a valid
+// struct or the query itself fails.
+func QueryInto[T any](nc *NotionClient, id string, query *api.QueryDB) ([]T, []*RowError, error) {
+	var zero T
+	if err := api.CheckUnmarshalTarget(&zero); err != nil {
+		return nil, nil, err
+	}
+
+	pages, err := nc.QueryDatabase(id, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ret := make([]T, len(pages))
+	var rowErrs []*RowError
+
+	for i, pg := range pages {
+		if err := api.UnmarshalPage(pg, &ret[i]); err != nil {
+			rowErrs = append(rowErrs, &RowError{Index: i, PageID: pg.ID, Err: err})
+		}
+	}
+
+	return ret, rowErrs, nil
+}
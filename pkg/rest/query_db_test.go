@@ -120,6 +120,25 @@ func TestQueryDatabase_NilQuery(t *testing.T) {
 	}
 }
 
+func TestQueryDatabase_InvalidFilter(t *testing.T) {
+	var called int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&called, 1)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.QueryDatabase("db-123", &api.QueryDB{
+		Filter: &api.Filter{Property: "Score", Number: &api.NumberFilter{}},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid filter")
+	}
+	if atomic.LoadInt32(&called) != 0 {
+		t.Error("expected no request to be made")
+	}
+}
+
 func TestQueryDatabase_Non200Response(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusForbidden)
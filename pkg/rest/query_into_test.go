@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+type queryIntoBook struct {
+	api.CommonObject
+	Name  string `notion:"Name,title"`
+	Pages int    `notion:"Pages"`
+}
+
+func TestQueryInto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/data_sources/ds-123/query" {
+			t.Errorf("expected path /v1/data_sources/ds-123/query, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.PageResponseList{
+			Response: api.Response{Object: "list"},
+			Results: []api.Page{
+				{
+					CommonObject: api.CommonObject{ID: "page-1"},
+					Properties: map[string]api.ValueProperty{
+						"Name":  {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("Dune")}},
+						"Pages": {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(412.0)},
+					},
+				},
+				{
+					CommonObject: api.CommonObject{ID: "page-2"},
+					Properties: map[string]api.ValueProperty{
+						"Name":  {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("Half")}},
+						"Pages": {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(1.5)},
+					},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	books, rowErrs, err := QueryInto[queryIntoBook](newTestClient(server.URL), "ds-123", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(books) != 2 {
+		t.Fatalf("expected 2 books, got %d", len(books))
+	}
+	if books[0].ID != "page-1" || books[0].Name != "Dune" || books[0].Pages != 412 {
+		t.Errorf("unexpected first book %+v", books[0])
+	}
+	if books[1].Name != "Half" {
+		t.Errorf("expected the failing row to keep its other properties, got %+v", books[1])
+	}
+
+	if len(rowErrs) != 1 {
+		t.Fatalf("expected 1 row error, got %v", rowErrs)
+	}
+	if rowErrs[0].Index != 1 || rowErrs[0].PageID != "page-2" {
+		t.Errorf("unexpected row error %v", rowErrs[0])
+	}
+
+	var propErrs api.PropertyErrors
+	if !errors.As(rowErrs[0], &propErrs) || propErrs[0].Property != "Pages" {
+		t.Errorf("expected a property error for Pages, got %v", rowErrs[0].Err)
+	}
+}
+
+func TestQueryInto_QueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "not found"})
+	}))
+	defer server.Close()
+
+	books, rowErrs, err := QueryInto[queryIntoBook](newTestClient(server.URL), "ds-123", nil)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if books != nil || rowErrs != nil {
+		t.Errorf("expected no results, got %v, %v", books, rowErrs)
+	}
+}
+
+func TestQueryInto_InvalidStruct(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	type noType struct {
+		Cover []byte `notion:"Cover"`
+	}
+
+	if _, _, err := QueryInto[noType](newTestClient(server.URL), "ds-123", nil); err == nil || !contains(err.Error(), "needs a property type") {
+		t.Errorf("unexpected error for a field without a type: %v", err)
+	}
+	if _, _, err := QueryInto[string](newTestClient(server.URL), "ds-123", nil); err == nil || !contains(err.Error(), "not a pointer to a struct") {
+		t.Errorf("unexpected error for a string: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request, got %d", requests)
+	}
+}
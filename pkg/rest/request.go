@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// doRequest sends an authenticated request to the Notion API endpoint made of the given path
+// elements. The body, if not nil, is sent as JSON and a successful response is decoded into out, if
+// not nil. The action describes the request in error messages, e.g. "listing users".
+func (nc *NotionClient) doRequest(method, action string, query url.Values, body, out interface{}, elem ...string) error {
+	var reqBody io.Reader
+	contentType := ""
+
+	if body != nil {
+		b := new(bytes.Buffer)
+		if err := json.NewEncoder(b).Encode(body); err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		reqBody = b
+		contentType = "application/json"
+	}
+
+	return nc.send(method, action, query, contentType, reqBody, out, elem...)
+}
+
+// send is like doRequest but sends the body as is, with the given content type.
+func (nc *NotionClient) send(method, action string, query url.Values, contentType string, body io.Reader, out interface{}, elem ...string) error {
+	client := &http.Client{}
+
+	u, err := url.Parse(nc.getBaseURL())
+	if err != nil {
+		return fmt.Errorf("parsing the APIURL: %w", err)
+	}
+
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("building query: %w", err)
+	}
+
+	req.Header.Add("Notion-Version", NotionVersion)
+	req.Header.Add("Authorization", "Bearer "+nc.token)
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+
+	defer resp.Body.Close()
+
+	return decodeResponse(resp, out)
+}
+
+// decodeResponse reads the response and decodes it into out, if not nil. A non-200 response is
+// turned into an error carrying the message returned by Notion.
+func decodeResponse(resp *http.Response, out interface{}) error {
+	data, respErr := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		failedResp := api.FailureResponse{}
+
+		if respErr != nil {
+			log.Debugf("reading the response: %v", respErr)
+		} else {
+			if err := json.Unmarshal(data, &failedResp); err != nil {
+				log.Debugf("unmarshalling failure response: %v", err)
+			}
+		}
+
+		return fmt.Errorf("http request returned non-200 response: %q. Message: %s",
+			resp.Status, failedResp.Message)
+	}
+
+	// Check if there is any error while reading the response Data.
+	if respErr != nil {
+		return fmt.Errorf("reading the response: %w", respErr)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("could not unmarshal response, %w", err)
+	}
+
+	return nil
+}
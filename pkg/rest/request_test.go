@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestDoRequest_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/things/abc" {
+			t.Errorf("expected path /v1/things/abc, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("page_size") != "10" {
+			t.Errorf("expected page_size 10, got %q", r.URL.Query().Get("page_size"))
+		}
+		if r.Header.Get("Notion-Version") != NotionVersion {
+			t.Errorf("expected Notion-Version %s, got %s", NotionVersion, r.Header.Get("Notion-Version"))
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected Authorization 'Bearer test-token', got %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %s", r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var in map[string]string
+		if err := json.Unmarshal(body, &in); err != nil || in["hello"] != "world" {
+			t.Errorf("unexpected request body: %s", body)
+		}
+
+		json.NewEncoder(w).Encode(api.User{ID: "user-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	q := url.Values{}
+	q.Add("page_size", "10")
+
+	var out api.User
+	err := client.doRequest("POST", "doing things", q, map[string]string{"hello": "world"}, &out, "v1/things", "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID != "user-1" {
+		t.Errorf("expected ID %q, got %q", "user-1", out.ID)
+	}
+}
+
+func TestDoRequest_NoBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "" {
+			t.Errorf("expected no Content-Type, got %s", r.Header.Get("Content-Type"))
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.doRequest("GET", "doing things", nil, nil, nil, "v1/things"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDoRequest_Non200Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(api.FailureResponse{
+			Object:  "error",
+			Status:  404,
+			Code:    "object_not_found",
+			Message: "Could not find thing",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	err := client.doRequest("GET", "doing things", nil, nil, nil, "v1/things")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "Could not find thing") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestDoRequest_InvalidResponseJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	var out api.User
+	err := client.doRequest("GET", "doing things", nil, nil, &out, "v1/things")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "unmarshal response") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestDoRequest_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	err := client.doRequest("GET", "doing things", nil, nil, nil, "v1/things")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "doing things") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestDoRequest_InvalidBaseURL(t *testing.T) {
+	client := NewNotionClient(WithBaseURL("://invalid-url"))
+	err := client.doRequest("GET", "doing things", nil, nil, nil, "v1/things")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "parsing the APIURL") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
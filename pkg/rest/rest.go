@@ -22,4 +22,10 @@ const (
 
 	// SubPathUsers is the Notion API sub path for querying users.
 	SubPathUsers = "v1/users"
+
+	// SubPathComments is the Notion API sub path for querying and creating comments.
+	SubPathComments = "v1/comments"
+
+	// SubPathFileUploads is the Notion API sub path for uploading files.
+	SubPathFileUploads = "v1/file_uploads"
 )
@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// ValidatePage checks the properties of the page against the schema of the data source or
+// database it is added to, and returns every problem at once as api.SchemaErrors. Pages whose
+// parent is not a data source or database are not checked. The schema is fetched once and cached.
+func (nc *NotionClient) ValidatePage(pg api.Page) error {
+	ds, err := nc.schemaFor(pg.Parent)
+	if err != nil || ds == nil {
+		return err
+	}
+
+	return api.ValidatePage(pg.Properties, ds.Properties)
+}
+
+// ClearSchemaCache drops the schemas cached by ValidatePage, so that they are fetched again.
+func (nc *NotionClient) ClearSchemaCache() {
+	nc.schemaMu.Lock()
+	defer nc.schemaMu.Unlock()
+
+	nc.schemas = nil
+}
+
+// preflight validates the page before AddPage sends it, when enabled by WithPageValidation, and
+// creates its missing select options when enabled by WithAutoCreateOptions.
+func (nc *NotionClient) preflight(pg api.Page) error {
+	if !nc.validatePages {
+		return nil
+	}
+
+	ds, err := nc.schemaFor(pg.Parent)
+	if err != nil || ds == nil {
+		return err
+	}
+
+	if missing := api.MissingOptions(pg.Properties, ds.Properties); nc.createOptions && missing != nil {
+		if ds, err = nc.addOptions(pg.Parent, ds, pg.Properties); err != nil {
+			return err
+		}
+	}
+
+	if err := api.ValidatePage(pg.Properties, ds.Properties); err != nil {
+		return fmt.Errorf("invalid page: %w", err)
+	}
+
+	return nil
+}
+
+// schemaFor returns the data source pages with the given parent are added to, or nil if the parent
+// is not a data source or database.
+func (nc *NotionClient) schemaFor(parent api.Parent) (*api.DataSource, error) {
+	key := schemaKey(parent)
+	if key == "" {
+		return nil, nil
+	}
+
+	nc.schemaMu.Lock()
+	ds, ok := nc.schemas[key]
+	nc.schemaMu.Unlock()
+	if ok {
+		return ds, nil
+	}
+
+	var err error
+	if parent.DataSourceID != "" {
+		ds, err = nc.GetDataSource(parent.DataSourceID)
+	} else {
+		ds, err = nc.databaseSchema(parent.DatabaseID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting the schema of %s: %w", key, err)
+	}
+
+	nc.cacheSchema(key, ds)
+
+	return ds, nil
+}
+
+// databaseSchema returns the data source of a database. Pages can only be added to a database
+// that has a single data source. A database that lists no data source, as returned by older
+// versions of the API, gives a data source without ID, holding the properties of the database.
+func (nc *NotionClient) databaseSchema(id string) (*api.DataSource, error) {
+	db, err := nc.GetDatabase(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(db.DataSources) == 1:
+		return nc.GetDataSource(db.DataSources[0].ID)
+	case len(db.DataSources) == 0 && db.Properties != nil:
+		return &api.DataSource{
+			Title:      db.Title,
+			Properties: db.Properties,
+			Parent:     api.Parent{Type: api.ParentTypeDatabase, DatabaseID: db.ID},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("database has %d data sources, add the page to one of them", len(db.DataSources))
+}
+
+// schemaKey returns the id of the data source or database of the parent.
+func schemaKey(parent api.Parent) string {
+	if parent.DataSourceID != "" {
+		return parent.DataSourceID
+	}
+
+	return parent.DatabaseID
+}
+
+func (nc *NotionClient) cacheSchema(key string, ds *api.DataSource) {
+	nc.schemaMu.Lock()
+	defer nc.schemaMu.Unlock()
+
+	if nc.schemas == nil {
+		nc.schemas = map[string]*api.DataSource{}
+	}
+	nc.schemas[key] = ds
+}
+
+// addOptions adds the options of the property values missing from the data source, and returns the
+// updated data source. The data source is fetched again first, as the options sent replace the
+// existing ones and the cached schema may lack options added in Notion since.
+func (nc *NotionClient) addOptions(parent api.Parent, ds *api.DataSource, props map[string]api.ValueProperty) (*api.DataSource, error) {
+	if ds.ID == "" {
+		return nil, fmt.Errorf("creating select options: database %s lists no data source to add them to, "+
+			"add the page to its data source instead", ds.Parent.DatabaseID)
+	}
+
+	ds, err := nc.GetDataSource(ds.ID)
+	if err != nil {
+		return nil, fmt.Errorf("creating select options: %w", err)
+	}
+
+	missing := api.MissingOptions(props, ds.Properties)
+	if missing == nil {
+		nc.cacheSchema(schemaKey(parent), ds)
+		return ds, nil
+	}
+
+	update := api.UpdateDataSourceRequest{Properties: map[string]api.Property{}}
+
+	for name, names := range missing {
+		prop := ds.Properties[name]
+
+		// The options sent replace the existing ones, so they are all listed.
+		var sel api.Select
+		if prop.Select != nil {
+			sel.Options = append(sel.Options, prop.Select.Options...)
+		}
+		if prop.MultiSelect != nil {
+			sel.Options = append(sel.Options, prop.MultiSelect.Options...)
+		}
+		for _, n := range names {
+			sel.Options = append(sel.Options, api.Option{Name: n})
+		}
+
+		if prop.Type == string(api.ValuePropertyTypeMultiSelect) {
+			update.Properties[name] = api.Property{MultiSelect: &sel}
+		} else {
+			update.Properties[name] = api.Property{Select: &sel}
+		}
+	}
+
+	updated, err := nc.UpdateDataSource(ds.ID, update)
+	if err != nil {
+		return nil, fmt.Errorf("creating select options: %w", err)
+	}
+
+	nc.cacheSchema(schemaKey(parent), updated)
+
+	return updated, nil
+}
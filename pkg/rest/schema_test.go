@@ -0,0 +1,228 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+var schemaDataSource = api.DataSource{
+	CommonObject: api.CommonObject{ID: "ds-1"},
+	Properties: map[string]api.Property{
+		"Name": {ID: "title", Type: "title"},
+		"Tags": {ID: "tg", Type: "multi_select", MultiSelect: &api.Select{Options: []api.Option{{ID: "o1", Name: "go"}}}},
+	},
+}
+
+// schemaServer serves the data source ds-1 and database db-1, counting the requests by path.
+func schemaServer(t *testing.T, counts map[string]*int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		if counts[key] == nil {
+			t.Errorf("unexpected request %s", key)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(counts[key], 1)
+
+		switch key {
+		case "GET /v1/databases/db-1":
+			w.Write([]byte(`{"object":"database","id":"db-1","data_sources":[{"id":"ds-1"}]}`))
+		case "GET /v1/databases/db-legacy":
+			w.Write([]byte(`{"object":"database","id":"db-legacy","properties":{"Name":{"id":"title","type":"title"},` +
+				`"Tags":{"id":"tg","type":"multi_select","multi_select":{"options":[{"id":"o1","name":"go"}]}}}}`))
+		case "GET /v1/data_sources/ds-1":
+			json.NewEncoder(w).Encode(schemaDataSource)
+		case "PATCH /v1/data_sources/ds-1":
+			var update api.UpdateDataSourceRequest
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &update)
+
+			tags := schemaDataSource.Properties["Tags"]
+			tags.MultiSelect = update.Properties["Tags"].MultiSelect
+			if opts := tags.MultiSelect.Options; len(opts) != 2 || opts[0].ID != "o1" || opts[1].Name != "rust" {
+				t.Errorf("expected the existing and the new option, got %+v", opts)
+			}
+
+			ds := schemaDataSource
+			ds.Properties = map[string]api.Property{"Name": ds.Properties["Name"], "Tags": tags}
+			json.NewEncoder(w).Encode(ds)
+		case "POST /v1/pages":
+			json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-1"}})
+		}
+	}))
+}
+
+func tagsPage(parent api.Parent, tags ...string) api.Page {
+	pg := api.Page{Parent: parent, Properties: map[string]api.ValueProperty{
+		"Name": {Type: api.ValuePropertyTypeTitle, Title: []api.RichText{api.NewText("Dune")}},
+		"Tags": {Type: api.ValuePropertyTypeMultiSelect},
+	}}
+	for _, tag := range tags {
+		tags := pg.Properties["Tags"]
+		tags.MultiSelect = append(tags.MultiSelect, api.Option{Name: tag})
+		pg.Properties["Tags"] = tags
+	}
+
+	return pg
+}
+
+func TestAddPage_PageValidation(t *testing.T) {
+	var getDB, getDS, post int32
+	server := schemaServer(t, map[string]*int32{
+		"GET /v1/databases/db-1":    &getDB,
+		"GET /v1/data_sources/ds-1": &getDS,
+		"POST /v1/pages":            &post,
+	})
+	defer server.Close()
+
+	client := NewNotionClient(WithSecretToken("test-token"), WithBaseURL(server.URL), WithPageValidation())
+	parent := api.Parent{Type: api.ParentTypeDatabase, DatabaseID: "db-1"}
+
+	if _, err := client.AddPage(tagsPage(parent, "go")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.AddPage(tagsPage(parent, "go", "rust"))
+
+	var errs api.SchemaErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "Tags.multi_select[1]" {
+		t.Fatalf("expected an unknown option error, got %v", err)
+	}
+
+	if getDB != 1 || getDS != 1 {
+		t.Errorf("expected the schema to be fetched once, got %d database and %d data source requests", getDB, getDS)
+	}
+	if post != 1 {
+		t.Errorf("expected only the valid page to be sent, got %d requests", post)
+	}
+
+	client.ClearSchemaCache()
+	if err := client.ValidatePage(tagsPage(parent)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if getDS != 2 {
+		t.Errorf("expected the schema to be fetched again after ClearSchemaCache, got %d requests", getDS)
+	}
+}
+
+func TestAddPage_AutoCreateOptions(t *testing.T) {
+	var getDS, patch, post int32
+	server := schemaServer(t, map[string]*int32{
+		"GET /v1/data_sources/ds-1":   &getDS,
+		"PATCH /v1/data_sources/ds-1": &patch,
+		"POST /v1/pages":              &post,
+	})
+	defer server.Close()
+
+	client := NewNotionClient(WithSecretToken("test-token"), WithBaseURL(server.URL), WithAutoCreateOptions())
+	parent := api.Parent{Type: api.ParentTypeDataSource, DataSourceID: "ds-1"}
+
+	if _, err := client.AddPage(tagsPage(parent, "go", "rust")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.AddPage(tagsPage(parent, "rust")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if getDS != 2 || patch != 1 || post != 2 {
+		t.Errorf("expected 2 schema requests, 1 update and 2 pages, got %d, %d and %d", getDS, patch, post)
+	}
+
+	_, err := client.AddPage(api.Page{Parent: parent, Properties: map[string]api.ValueProperty{
+		"Nmae": {Type: api.ValuePropertyTypeTitle},
+	}})
+	if err == nil || !contains(err.Error(), "invalid page: Nmae: unknown property") {
+		t.Errorf("expected unknown property error, got %v", err)
+	}
+}
+
+func TestAddPage_AutoCreateOptions_StaleSchema(t *testing.T) {
+	var gets int32
+	var sent []api.Option
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ds := schemaDataSource
+		tags := ds.Properties["Tags"]
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/data_sources/ds-1":
+			// The option sql is added in Notion once the schema is cached.
+			if atomic.AddInt32(&gets, 1) > 1 {
+				tags.MultiSelect = &api.Select{Options: []api.Option{{ID: "o1", Name: "go"}, {ID: "o2", Name: "sql"}}}
+			}
+		case "PATCH /v1/data_sources/ds-1":
+			var update api.UpdateDataSourceRequest
+			json.NewDecoder(r.Body).Decode(&update)
+			tags.MultiSelect = update.Properties["Tags"].MultiSelect
+			sent = tags.MultiSelect.Options
+		case "POST /v1/pages":
+			json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-1"}})
+			return
+		}
+
+		ds.Properties = map[string]api.Property{"Name": ds.Properties["Name"], "Tags": tags}
+		json.NewEncoder(w).Encode(ds)
+	}))
+	defer server.Close()
+
+	client := NewNotionClient(WithSecretToken("test-token"), WithBaseURL(server.URL), WithAutoCreateOptions())
+	parent := api.Parent{Type: api.ParentTypeDataSource, DataSourceID: "ds-1"}
+
+	if err := client.ValidatePage(tagsPage(parent, "go")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.AddPage(tagsPage(parent, "rust")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sent) != 3 || sent[0].ID != "o1" || sent[1].ID != "o2" || sent[2].Name != "rust" {
+		t.Errorf("expected the options of Notion and the new one, got %+v", sent)
+	}
+}
+
+func TestAddPage_AutoCreateOptions_LegacyDatabase(t *testing.T) {
+	var getDB, post int32
+	server := schemaServer(t, map[string]*int32{
+		"GET /v1/databases/db-legacy": &getDB,
+		"POST /v1/pages":              &post,
+	})
+	defer server.Close()
+
+	client := NewNotionClient(WithSecretToken("test-token"), WithBaseURL(server.URL), WithAutoCreateOptions())
+	parent := api.Parent{Type: api.ParentTypeDatabase, DatabaseID: "db-legacy"}
+
+	if _, err := client.AddPage(tagsPage(parent, "go")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.AddPage(tagsPage(parent, "rust"))
+	if err == nil || !contains(err.Error(), "database db-legacy lists no data source") {
+		t.Errorf("expected an error for options of a legacy database, got %v", err)
+	}
+	if getDB != 1 || post != 1 {
+		t.Errorf("expected 1 schema request and 1 page, got %d and %d", getDB, post)
+	}
+}
+
+func TestValidatePage_Parents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"object":"database","id":"db-2","data_sources":[{"id":"ds-1"},{"id":"ds-2"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.ValidatePage(api.Page{Parent: api.Parent{Type: api.ParentTypePage, PageID: "p"}}); err != nil {
+		t.Errorf("expected pages under a page not to be checked, got %v", err)
+	}
+
+	err := client.ValidatePage(api.Page{Parent: api.Parent{Type: api.ParentTypeDatabase, DatabaseID: "db-2"}})
+	if err == nil || !contains(err.Error(), "database has 2 data sources") {
+		t.Errorf("expected error for a database with several data sources, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// ListTemplates takes a data source id and returns the page templates of the data source. A page
+// is created from one of them with the Template field of the page.
+func (nc *NotionClient) ListTemplates(dataSourceID string) ([]api.Template, error) {
+	return nc.listTemplates(dataSourceID, "")
+}
+
+// FindTemplate takes a data source id and the name of a template, e.g. "Daily Standup", and
+// returns the template of the data source with that name.
+func (nc *NotionClient) FindTemplate(dataSourceID, name string) (*api.Template, error) {
+	templates, err := nc.listTemplates(dataSourceID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notion also returns the templates whose name only contains the one searched.
+	for _, t := range templates {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("template %q not found", name)
+}
+
+func (nc *NotionClient) listTemplates(dataSourceID, name string) ([]api.Template, error) {
+	hasMore := true
+	startCursor := ""
+	var ret []api.Template
+
+	for hasMore {
+		q := url.Values{}
+		q.Add("page_size", "100")
+		if name != "" {
+			q.Add("name", name)
+		}
+		if startCursor != "" {
+			q.Add("start_cursor", startCursor)
+		}
+
+		templates := api.TemplateResponseList{}
+		if err := nc.doRequest("GET", "listing templates", q, nil, &templates, SubPathDataSources, dataSourceID, "templates"); err != nil {
+			return nil, err
+		}
+
+		hasMore = templates.HasMore
+		startCursor = templates.NextCursor
+
+		ret = append(ret, templates.Templates...)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+	"github.com/surajssd/libnotion/api/blocks"
+)
+
+func TestListTemplates_Pagination(t *testing.T) {
+	var callCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/data_sources/ds-1/templates" {
+			t.Errorf("expected path /v1/data_sources/ds-1/templates, got %s", r.URL.Path)
+		}
+
+		if count == 1 {
+			json.NewEncoder(w).Encode(api.TemplateResponseList{
+				Response:  api.Response{HasMore: true, NextCursor: "cursor-abc"},
+				Templates: []api.Template{{ID: "t-1", Name: "Daily Standup", IsDefault: true}},
+			})
+			return
+		}
+
+		if c := r.URL.Query().Get("start_cursor"); c != "cursor-abc" {
+			t.Errorf("expected start_cursor %q, got %q", "cursor-abc", c)
+		}
+		json.NewEncoder(w).Encode(api.TemplateResponseList{
+			Templates: []api.Template{{ID: "t-2", Name: "Retro"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	templates, err := client.ListTemplates("ds-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(templates))
+	}
+	if !templates[0].IsDefault || templates[1].Name != "Retro" {
+		t.Errorf("unexpected templates: %+v", templates)
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name := r.URL.Query().Get("name"); name == "" {
+			t.Error("expected the name to be searched")
+		}
+		json.NewEncoder(w).Encode(api.TemplateResponseList{
+			Templates: []api.Template{{ID: "t-1", Name: "Daily Standup (old)"}, {ID: "t-2", Name: "Daily Standup"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	template, err := client.FindTemplate("ds-1", "Daily Standup")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.ID != "t-2" {
+		t.Errorf("expected template t-2, got %s", template.ID)
+	}
+
+	if _, err := client.FindTemplate("ds-1", "Daily"); err == nil || err.Error() != `template "Daily" not found` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestAddPage_Template(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		if got := string(body["template"]); got != `{"type":"template_id","template_id":"t-2"}` {
+			t.Errorf("unexpected template %s", got)
+		}
+		json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	pg := api.Page{
+		Parent:   api.Parent{Type: api.ParentTypeDataSource, DataSourceID: "ds-1"},
+		Template: &api.PageTemplate{Type: api.PageTemplateTypeTemplateID, TemplateID: "t-2"},
+	}
+	if _, err := client.AddPage(pg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := client.AddPageWithContent(pg, []blocks.Block{paragraph("notes")})
+	if err == nil || !contains(err.Error(), "a page created from a template cannot have content") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package rest
+
+import (
+	"github.com/surajssd/libnotion/api"
+)
+
+// UpdateDataSource takes a data source id and an update request and changes the property schema
+// of the data source. Properties that are not part of the request are left unchanged.
+func (nc *NotionClient) UpdateDataSource(id string, update api.UpdateDataSourceRequest) (*api.DataSource, error) {
+	ds := api.DataSource{}
+	if err := nc.doRequest("PATCH", "updating data source", nil, update, &ds, SubPathDataSources, id); err != nil {
+		return nil, err
+	}
+
+	return &ds, nil
+}
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// UpdatePage takes a page id and an update request and changes the given properties of the page.
+// Properties that are not part of the request are left unchanged. Title and rich text values
+// longer than Notion's limits are split, see api.SplitProperties.
+func (nc *NotionClient) UpdatePage(id string, update api.UpdatePageRequest) (*api.Page, error) {
+	props, err := api.SplitProperties(update.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page update: %w", err)
+	}
+	update.Properties = props
+
+	page := api.Page{}
+	if err := nc.doRequest("PATCH", "updating page", nil, update, &page, SubPathPages, id); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestUpdateDataSource_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/data_sources/ds-1" {
+			t.Errorf("expected path /v1/data_sources/ds-1, got %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		want := `{"properties":{"Tags":{"multi_select":{"options":[{"name":"go"}]}}}}` + "\n"
+		if string(body) != want {
+			t.Errorf("expected body %s, got %s", want, body)
+		}
+
+		json.NewEncoder(w).Encode(api.DataSource{CommonObject: api.CommonObject{ID: "ds-1"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	ds, err := client.UpdateDataSource("ds-1", api.UpdateDataSourceRequest{
+		Properties: map[string]api.Property{
+			"Tags": {MultiSelect: &api.Select{Options: []api.Option{{Name: "go"}}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ds.ID != "ds-1" {
+		t.Errorf("expected data source ds-1, got %q", ds.ID)
+	}
+}
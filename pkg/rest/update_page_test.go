@@ -0,0 +1,115 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestUpdatePage_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/pages/page-123" {
+			t.Errorf("expected path /v1/pages/page-123, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected Authorization 'Bearer test-token', got %s", r.Header.Get("Authorization"))
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("reading request body: %v", err)
+		}
+		var got struct {
+			Properties map[string]map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("unmarshalling request body: %v", err)
+		}
+		if v := string(got.Properties["Pages"]["number"]); v != "0" {
+			t.Errorf("expected number 0, got %s", v)
+		}
+		if v := string(got.Properties["Due"]["date"]); v != "null" {
+			t.Errorf("expected date null, got %s", v)
+		}
+		if v := string(got.Properties["Done"]["checkbox"]); v != "false" {
+			t.Errorf("expected checkbox false, got %s", v)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.Page{CommonObject: api.CommonObject{ID: "page-123", Object: "page"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.UpdatePage("page-123", api.UpdatePageRequest{
+		Properties: map[string]api.ValueProperty{
+			"Pages": {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(0.0)},
+			"Due":   {Type: api.ValuePropertyTypeDate},
+			"Done":  {Type: api.ValuePropertyTypeCheckbox},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ID != "page-123" {
+		t.Errorf("expected page ID %q, got %q", "page-123", result.ID)
+	}
+}
+
+func TestUpdatePage_Non200Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(api.FailureResponse{
+			Object:  "error",
+			Status:  400,
+			Code:    "validation_error",
+			Message: "Pages is expected to be number.",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.UpdatePage("page-123", api.UpdatePageRequest{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "Pages is expected to be number.") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestUpdatePage_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.UpdatePage("page-123", api.UpdatePageRequest{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "updating page") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestUpdatePage_TooManyRuns(t *testing.T) {
+	var rts []api.RichText
+	for i := 0; i < 101; i++ {
+		rts = append(rts, api.NewEquation("x"))
+	}
+
+	client := newTestClient("http://127.0.0.1:0")
+	_, err := client.UpdatePage("page-1", api.UpdatePageRequest{Properties: map[string]api.ValueProperty{
+		"Notes": {Type: api.ValuePropertyTypeRichText, RichText: rts},
+	}})
+	if err == nil || !contains(err.Error(), `property "Notes" has 101 rich text elements, the limit is 100`) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// UpsertResult counts what Upsert and UpsertBatch did with the pages.
+type UpsertResult struct {
+	// Pages added because no page of the data source had their key.
+	Created int
+
+	// Pages of the data source whose properties were changed.
+	Updated int
+
+	// Pages of the data source that already had the same property values, and were not written.
+	Unchanged int
+}
+
+// Upsert adds the page to the data source, unless a page of the data source has the same value of
+// the key property, e.g. an external ID. That page is updated with the properties of pg instead,
+// or left as is if it already has the same values, see api.EqualValues. The other properties of pg
+// can be keyed by name or ID. The key property is a title, text, number, URL, email, phone number,
+// select or status property, and is given by name.
+//
+// The parent of pg defaults to the data source. The icon, cover and template of pg are only used
+// when it is created. Upserts of the same key by the client are run one at a time, but an error is
+// returned if several pages of the data source already have the key.
+func (nc *NotionClient) Upsert(dataSourceID, keyProperty string, pg api.Page) (*api.Page, UpsertResult, error) {
+	key, err := upsertKey(keyProperty, pg)
+	if err != nil {
+		return nil, UpsertResult{}, err
+	}
+
+	unlock := nc.lockKeys(dataSourceID, keyProperty, []string{key})
+	defer unlock()
+
+	filter, err := keyFilter(keyProperty, pg.Properties[keyProperty], false)
+	if err != nil {
+		return nil, UpsertResult{}, err
+	}
+	pages, err := nc.QueryDatabase(dataSourceID, &api.QueryDB{Filter: filter, PageSize: 100})
+	if err != nil {
+		return nil, UpsertResult{}, fmt.Errorf("looking up key %q: %w", key, err)
+	}
+
+	var res UpsertResult
+	ret, err := nc.upsertPage(dataSourceID, pg, indexPages(keyProperty, pages)[key], &res)
+	return ret, res, err
+}
+
+// UpsertBatch is like Upsert for several pages, which must have different keys. The pages of the
+// data source that have a value for the key property are fetched at once first. It stops at the
+// first page that fails, and returns what was done so far with the error.
+func (nc *NotionClient) UpsertBatch(dataSourceID, keyProperty string, pages []api.Page) (UpsertResult, error) {
+	var res UpsertResult
+	if len(pages) == 0 {
+		return res, nil
+	}
+
+	keys := make([]string, len(pages))
+	seen := map[string]int{}
+	for i, pg := range pages {
+		key, err := upsertKey(keyProperty, pg)
+		if err != nil {
+			return res, fmt.Errorf("page %d: %w", i, err)
+		}
+		if t := pages[0].Properties[keyProperty].Type; pg.Properties[keyProperty].Type != t {
+			return res, fmt.Errorf("page %d: key property %q is of type %s in page 0", i, keyProperty, t)
+		}
+		if j, ok := seen[key]; ok {
+			return res, fmt.Errorf("pages %d and %d have the same key %q", j, i, key)
+		}
+		seen[key] = i
+		keys[i] = key
+	}
+
+	unlock := nc.lockKeys(dataSourceID, keyProperty, keys)
+	defer unlock()
+
+	filter, err := keyFilter(keyProperty, pages[0].Properties[keyProperty], true)
+	if err != nil {
+		return res, err
+	}
+	existing, err := nc.QueryDatabase(dataSourceID, &api.QueryDB{Filter: filter, PageSize: 100})
+	if err != nil {
+		return res, fmt.Errorf("looking up keys: %w", err)
+	}
+	index := indexPages(keyProperty, existing)
+
+	for i, pg := range pages {
+		if _, err := nc.upsertPage(dataSourceID, pg, index[keys[i]], &res); err != nil {
+			return res, fmt.Errorf("page %d (key %q): %w", i, keys[i], err)
+		}
+	}
+
+	return res, nil
+}
+
+// upsertPage adds pg, or updates the page of the data source with its key, and counts it in res.
+func (nc *NotionClient) upsertPage(dataSourceID string, pg api.Page, existing []api.Page, res *UpsertResult) (*api.Page, error) {
+	switch len(existing) {
+	case 0:
+		if pg.Parent.Type == "" {
+			pg.Parent = api.Parent{Type: api.ParentTypeDataSource, DataSourceID: dataSourceID}
+		}
+		ret, err := nc.AddPage(pg)
+		if err != nil {
+			return nil, err
+		}
+		res.Created++
+		return ret, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("%d pages of the data source have this key", len(existing))
+	}
+
+	old := existing[0]
+	changed := map[string]api.ValueProperty{}
+	for key, vp := range pg.Properties {
+		name, err := nc.propertyName(dataSourceID, old, key)
+		if err != nil {
+			return nil, err
+		}
+		if ov, ok := old.Properties[name]; !ok || !api.EqualValues(vp, ov) {
+			changed[key] = vp
+		}
+	}
+
+	if len(changed) == 0 {
+		res.Unchanged++
+		return &old, nil
+	}
+
+	ret, err := nc.UpdatePage(old.ID, api.UpdatePageRequest{Properties: changed})
+	if err != nil {
+		return nil, err
+	}
+	res.Updated++
+
+	return ret, nil
+}
+
+// propertyName returns the name of the property of the page given by key, which is a name or an
+// ID. IDs are looked up in the schema of the data source. Unknown keys are returned as is.
+func (nc *NotionClient) propertyName(dataSourceID string, pg api.Page, key string) (string, error) {
+	if _, ok := pg.Properties[key]; ok {
+		return key, nil
+	}
+
+	ds, err := nc.schemaFor(api.Parent{Type: api.ParentTypeDataSource, DataSourceID: dataSourceID})
+	if err != nil {
+		return "", err
+	}
+	for name, p := range ds.Properties {
+		if p.ID == key {
+			return name, nil
+		}
+	}
+
+	return key, nil
+}
+
+// upsertKey returns the value of the key property of the page.
+func upsertKey(keyProperty string, pg api.Page) (string, error) {
+	vp, ok := pg.Properties[keyProperty]
+	if !ok {
+		return "", fmt.Errorf("page has no value for the key property %q", keyProperty)
+	}
+
+	key, ok := keyValue(vp)
+	if !ok {
+		return "", fmt.Errorf("key property %q: %s properties cannot be used as keys", keyProperty, vp.Type)
+	}
+	if key == "" {
+		return "", fmt.Errorf("page has an empty value for the key property %q", keyProperty)
+	}
+
+	return key, nil
+}
+
+// keyValue returns the value of a property as a key. It returns false for the property types that
+// cannot be used as keys.
+func keyValue(vp api.ValueProperty) (string, bool) {
+	switch vp.Type {
+	case api.ValuePropertyTypeTitle:
+		return api.PlainText(vp.Title), true
+	case api.ValuePropertyTypeRichText:
+		return api.PlainText(vp.RichText), true
+	case api.ValuePropertyTypeURL:
+		return vp.URL, true
+	case api.ValuePropertyTypeEmail:
+		return vp.Email, true
+	case api.ValuePropertyTypePhoneNumber:
+		return vp.PhoneNumber, true
+	case api.ValuePropertyTypeNumber:
+		if vp.Number == nil {
+			return "", true
+		}
+		return strconv.FormatFloat(*vp.Number, 'f', -1, 64), true
+	case api.ValuePropertyTypeSelect:
+		if vp.Select == nil {
+			return "", true
+		}
+		return vp.Select.Name, true
+	case api.ValuePropertyTypeStatus:
+		if vp.Status == nil {
+			return "", true
+		}
+		return vp.Status.Name, true
+	}
+
+	return "", false
+}
+
+// keyFilter returns the filter matching the pages with the key of vp, or with any key if anyKey is
+// set.
+func keyFilter(keyProperty string, vp api.ValueProperty, anyKey bool) (*api.Filter, error) {
+	key, _ := keyValue(vp)
+	if anyKey {
+		key = ""
+	}
+
+	text := &api.TextFilter{Equals: key, IsNotEmpty: anyKey}
+	option := &api.SelectFilter{Equals: key, IsNotEmpty: anyKey}
+
+	f := api.Filter{Property: keyProperty}
+	switch vp.Type {
+	case api.ValuePropertyTypeTitle:
+		f.Title = text
+	case api.ValuePropertyTypeRichText:
+		f.RichText = text
+	case api.ValuePropertyTypeURL:
+		f.URL = text
+	case api.ValuePropertyTypeEmail:
+		f.Email = text
+	case api.ValuePropertyTypePhoneNumber:
+		f.Phone = text
+	case api.ValuePropertyTypeNumber:
+		f.Number = &api.NumberFilter{IsNotEmpty: anyKey}
+		if !anyKey {
+			f.Number.Equals = vp.Number
+		}
+	case api.ValuePropertyTypeSelect:
+		f.Select = option
+	case api.ValuePropertyTypeStatus:
+		f.Status = &api.StatusFilter{Equals: key, IsNotEmpty: anyKey}
+	default:
+		return nil, fmt.Errorf("key property %q: %s properties cannot be used as keys", keyProperty, vp.Type)
+	}
+
+	return &f, nil
+}
+
+// indexPages groups the pages by their key. The key of every page is compared again, as the text
+// filters of Notion are not meant for exact matches.
+func indexPages(keyProperty string, pages []api.Page) map[string][]api.Page {
+	ret := map[string][]api.Page{}
+	for _, pg := range pages {
+		if key, ok := keyValue(pg.Properties[keyProperty]); ok && key != "" {
+			ret[key] = append(ret[key], pg)
+		}
+	}
+
+	return ret
+}
+
+// keyLock serializes the upserts of a key.
+type keyLock struct {
+	sync.Mutex
+
+	// Number of upserts holding or waiting for the lock.
+	users int
+}
+
+// lockKeys locks the keys of the key property of the data source for the upserts of the client,
+// and returns the function unlocking them. Keys are locked in order so that batches do not
+// deadlock.
+func (nc *NotionClient) lockKeys(dataSourceID, keyProperty string, keys []string) func() {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = dataSourceID + "\x00" + keyProperty + "\x00" + key
+	}
+	sort.Strings(names)
+
+	locks := make([]*keyLock, len(names))
+	for i, name := range names {
+		nc.upsertMu.Lock()
+		if nc.upsertKeys == nil {
+			nc.upsertKeys = map[string]*keyLock{}
+		}
+		l, ok := nc.upsertKeys[name]
+		if !ok {
+			l = &keyLock{}
+			nc.upsertKeys[name] = l
+		}
+		l.users++
+		nc.upsertMu.Unlock()
+
+		l.Lock()
+		locks[i] = l
+	}
+
+	return func() {
+		for i, l := range locks {
+			l.Unlock()
+
+			nc.upsertMu.Lock()
+			if l.users--; l.users == 0 {
+				delete(nc.upsertKeys, names[i])
+			}
+			nc.upsertMu.Unlock()
+		}
+	}
+}
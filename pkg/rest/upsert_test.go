@@ -0,0 +1,235 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// fakeDataSource serves the pages of a data source. Queries return every page, like a filter
+// Notion matches loosely.
+type fakeDataSource struct {
+	mu      sync.Mutex
+	pages   []api.Page
+	queries int
+	writes  []string
+}
+
+func (f *fakeDataSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/v1/data_sources/ds-1":
+		json.NewEncoder(w).Encode(api.DataSource{CommonObject: api.CommonObject{ID: "ds-1"}, Properties: map[string]api.Property{
+			"ISBN":  {ID: "isbn", Type: string(api.ValuePropertyTypeRichText)},
+			"Name":  {ID: "title", Type: string(api.ValuePropertyTypeTitle)},
+			"Pages": {ID: "p%3A1", Type: string(api.ValuePropertyTypeNumber)},
+		}})
+	case r.Method == "POST" && r.URL.Path == "/v1/data_sources/ds-1/query":
+		f.queries++
+		json.NewEncoder(w).Encode(api.PageResponseList{Results: f.pages})
+	case r.Method == "POST" && r.URL.Path == "/v1/pages":
+		var pg api.Page
+		json.NewDecoder(r.Body).Decode(&pg)
+		pg.ID = fmt.Sprintf("page-%d", len(f.pages)+1)
+		f.pages = append(f.pages, pg)
+		f.writes = append(f.writes, "create "+pg.ID)
+		json.NewEncoder(w).Encode(pg)
+	case r.Method == "PATCH" && strings.HasPrefix(r.URL.Path, "/v1/pages/"):
+		var update api.UpdatePageRequest
+		json.NewDecoder(r.Body).Decode(&update)
+		id := strings.TrimPrefix(r.URL.Path, "/v1/pages/")
+
+		var names []string
+		for i := range f.pages {
+			if f.pages[i].ID == id {
+				for name, vp := range update.Properties {
+					f.pages[i].Properties[name] = vp
+					names = append(names, name)
+				}
+				json.NewEncoder(w).Encode(f.pages[i])
+			}
+		}
+		f.writes = append(f.writes, fmt.Sprintf("update %s %v", id, names))
+	default:
+		http.Error(w, "unexpected request "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+	}
+}
+
+func book(isbn, title string, pages float64) api.Page {
+	return api.Page{Properties: map[string]api.ValueProperty{
+		"ISBN":  {Type: api.ValuePropertyTypeRichText, RichText: api.NewTexts(isbn)},
+		"Name":  {Type: api.ValuePropertyTypeTitle, Title: api.NewTexts(title)},
+		"Pages": {Type: api.ValuePropertyTypeNumber, Number: api.Ptr(pages)},
+	}}
+}
+
+func TestUpsert(t *testing.T) {
+	ds := &fakeDataSource{}
+	server := httptest.NewServer(ds)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	steps := []struct {
+		page api.Page
+		want UpsertResult
+	}{
+		{book("978-0441013593", "Dune", 412), UpsertResult{Created: 1}},
+		{book("978-0441013593", "Dune", 412), UpsertResult{Unchanged: 1}},
+		{book("978-0441013593", "Dune", 896), UpsertResult{Updated: 1}},
+	}
+	for i, step := range steps {
+		pg, res, err := client.Upsert("ds-1", "ISBN", step.page)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if res != step.want {
+			t.Errorf("step %d: expected %+v, got %+v", i, step.want, res)
+		}
+		if pg.ID != "page-1" {
+			t.Errorf("step %d: expected page-1, got %s", i, pg.ID)
+		}
+	}
+
+	want := []string{"create page-1", "update page-1 [Pages]"}
+	if strings.Join(ds.writes, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected writes %v, got %v", want, ds.writes)
+	}
+	if ds.pages[0].Parent.DataSourceID != "ds-1" {
+		t.Errorf("expected the page to be added to the data source, got %+v", ds.pages[0].Parent)
+	}
+}
+
+func TestUpsert_Concurrent(t *testing.T) {
+	ds := &fakeDataSource{}
+	server := httptest.NewServer(ds)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Upsert("ds-1", "ISBN", book("978-0441013593", "Dune", 412)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ds.pages) != 1 {
+		t.Errorf("expected a single page to be created, got %d", len(ds.pages))
+	}
+	if len(client.upsertKeys) != 0 {
+		t.Errorf("expected the key locks to be released, got %d", len(client.upsertKeys))
+	}
+}
+
+func TestUpsertBatch(t *testing.T) {
+	ds := &fakeDataSource{pages: []api.Page{
+		book("1", "One", 100),
+		book("2", "Two", 200),
+		book("20", "Twenty", 300),
+	}}
+	for i := range ds.pages {
+		ds.pages[i].ID = fmt.Sprintf("existing-%d", i+1)
+	}
+	server := httptest.NewServer(ds)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	res, err := client.UpsertBatch("ds-1", "ISBN", []api.Page{
+		book("1", "One", 100),
+		book("2", "Two (2nd edition)", 200),
+		book("3", "Three", 300),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (UpsertResult{Created: 1, Updated: 1, Unchanged: 1}); res != want {
+		t.Errorf("expected %+v, got %+v", want, res)
+	}
+	if ds.queries != 1 {
+		t.Errorf("expected a single query, got %d", ds.queries)
+	}
+	want := []string{"update existing-2 [Name]", "create page-4"}
+	if strings.Join(ds.writes, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected writes %v, got %v", want, ds.writes)
+	}
+}
+
+func TestUpsert_Errors(t *testing.T) {
+	ds := &fakeDataSource{pages: []api.Page{book("1", "One", 100), book("1", "Uno", 100)}}
+	server := httptest.NewServer(ds)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	checkbox := book("2", "Two", 200)
+	checkbox.Properties["Read"] = api.ValueProperty{Type: api.ValuePropertyTypeCheckbox, Checkbox: true}
+
+	if _, _, err := client.Upsert("ds-1", "ISBN", book("1", "One", 100)); err == nil || err.Error() != "2 pages of the data source have this key" {
+		t.Errorf("unexpected error for a key used twice: %v", err)
+	}
+	if _, _, err := client.Upsert("ds-1", "Read", checkbox); err == nil || err.Error() != `key property "Read": checkbox properties cannot be used as keys` {
+		t.Errorf("unexpected error for a checkbox key: %v", err)
+	}
+	if _, _, err := client.Upsert("ds-1", "Missing", checkbox); err == nil || err.Error() != `page has no value for the key property "Missing"` {
+		t.Errorf("unexpected error for a missing key: %v", err)
+	}
+	if _, err := client.UpsertBatch("ds-1", "ISBN", []api.Page{book("2", "Two", 200), book("3", "Three", 1), book("2", "Deux", 200)}); err == nil || err.Error() != `pages 0 and 2 have the same key "2"` {
+		t.Errorf("unexpected error for a batch with the same key twice: %v", err)
+	}
+	if len(ds.writes) != 0 {
+		t.Errorf("expected no writes, got %v", ds.writes)
+	}
+}
+
+func TestUpsert_PropertyIDsAndFormatting(t *testing.T) {
+	ds := &fakeDataSource{pages: []api.Page{book("1", "One", 100)}}
+	ds.pages[0].ID = "existing-1"
+	server := httptest.NewServer(ds)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	byID := book("1", "One", 100)
+	byID.Properties["p%3A1"] = byID.Properties["Pages"]
+	delete(byID.Properties, "Pages")
+
+	bold := book("1", "One", 100)
+	bold.Properties["Name"] = api.ValueProperty{Type: api.ValuePropertyTypeTitle, Title: []api.RichText{{
+		Type:        api.RichTextTypeText,
+		Text:        &api.Text{Content: "One"},
+		Annotations: &api.Annotation{Bold: true},
+	}}}
+
+	steps := []struct {
+		page api.Page
+		want UpsertResult
+	}{
+		{byID, UpsertResult{Unchanged: 1}},
+		{bold, UpsertResult{Updated: 1}},
+	}
+	for i, step := range steps {
+		if _, res, err := client.Upsert("ds-1", "ISBN", step.page); err != nil || res != step.want {
+			t.Errorf("step %d: expected %+v, got %+v and error %v", i, step.want, res, err)
+		}
+	}
+
+	want := []string{"update existing-1 [Name]"}
+	if strings.Join(ds.writes, ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected writes %v, got %v", want, ds.writes)
+	}
+}
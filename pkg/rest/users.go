@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"net/url"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+// ListUsers returns all the users of the workspace, including bots. Guests are not included.
+func (nc *NotionClient) ListUsers() ([]api.User, error) {
+	hasMore := true
+	startCursor := ""
+	var ret []api.User
+
+	for hasMore {
+		q := url.Values{}
+		q.Add("page_size", "100")
+		if startCursor != "" {
+			q.Add("start_cursor", startCursor)
+		}
+
+		users := api.UserResponseList{}
+		if err := nc.doRequest("GET", "listing users", q, nil, &users, SubPathUsers); err != nil {
+			return nil, err
+		}
+
+		hasMore = users.HasMore
+		startCursor = users.NextCursor
+
+		ret = append(ret, users.Results...)
+	}
+
+	return ret, nil
+}
+
+// GetUser takes a user id and returns the user object. This is useful to resolve the partial
+// users in created_by and last_edited_by to names and emails.
+func (nc *NotionClient) GetUser(id string) (*api.User, error) {
+	user := api.User{}
+	if err := nc.doRequest("GET", "getting user", nil, nil, &user, SubPathUsers, id); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetMe returns the bot user associated with the token of the client. The returned user contains
+// the owner of the bot and the workspace name.
+func (nc *NotionClient) GetMe() (*api.User, error) {
+	return nc.GetUser("me")
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/surajssd/libnotion/api"
+)
+
+func TestListUsers_Pagination(t *testing.T) {
+	var callCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+
+		if r.Method != "GET" {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/users" {
+			t.Errorf("expected path /v1/users, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if count == 1 {
+			if c := r.URL.Query().Get("start_cursor"); c != "" {
+				t.Errorf("first request should have empty start_cursor, got %q", c)
+			}
+			json.NewEncoder(w).Encode(api.UserResponseList{
+				Response: api.Response{Object: "list", HasMore: true, NextCursor: "cursor-abc"},
+				Results:  []api.User{{ID: "user-1", Type: "person", Person: &api.Person{Email: "ada@example.com"}}},
+			})
+			return
+		}
+
+		if c := r.URL.Query().Get("start_cursor"); c != "cursor-abc" {
+			t.Errorf("expected start_cursor %q, got %q", "cursor-abc", c)
+		}
+		json.NewEncoder(w).Encode(api.UserResponseList{
+			Response: api.Response{Object: "list"},
+			Results:  []api.User{{ID: "bot-1", Type: "bot", Bot: &api.Bot{}}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	users, err := client.ListUsers()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	if users[0].Person == nil || users[0].Person.Email != "ada@example.com" {
+		t.Errorf("unexpected first user: %+v", users[0])
+	}
+	if users[1].ID != "bot-1" {
+		t.Errorf("expected user ID %q, got %q", "bot-1", users[1].ID)
+	}
+	if atomic.LoadInt32(&callCount) != 2 {
+		t.Errorf("expected 2 API calls, got %d", callCount)
+	}
+}
+
+func TestListUsers_Non200Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(api.FailureResponse{Message: "insufficient permissions"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.ListUsers()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "non-200 response") || !contains(got, "insufficient permissions") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestGetUser_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/users/user-1" {
+			t.Errorf("expected path /v1/users/user-1, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(api.User{ID: "user-1", Name: "Ada", Person: &api.Person{Email: "ada@example.com"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	user, err := client.GetUser("user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Name != "Ada" {
+		t.Errorf("expected name %q, got %q", "Ada", user.Name)
+	}
+}
+
+func TestGetUser_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := newTestClient(server.URL)
+	_, err := client.GetUser("user-1")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := err.Error(); !contains(got, "getting user") {
+		t.Errorf("unexpected error message: %s", got)
+	}
+}
+
+func TestGetMe_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/users/me" {
+			t.Errorf("expected path /v1/users/me, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(api.User{
+			ID:   "bot-1",
+			Type: "bot",
+			Bot:  &api.Bot{Owner: &api.Owner{Type: "workspace"}, WorkspaceName: "Acme"},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	me, err := client.GetMe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if me.Bot == nil || me.Bot.WorkspaceName != "Acme" {
+		t.Errorf("unexpected bot: %+v", me.Bot)
+	}
+}